@@ -0,0 +1,134 @@
+package galidator
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Configures async validation which is activated by WithAsync
+type asyncOptions struct {
+	// Maximum number of fields or elements which get validated at the same time, 0 means no limit
+	limit int
+	// Limits number of I/O-bound rules which run at the same time, nil means no limit
+	semaphore chan struct{}
+}
+
+// Returns a copy of passed context which activates async validation
+//
+// In async validation, fields and slice elements which have I/O-bound rules (see `Async` method of ruleSet)
+// get validated concurrently and at most limit I/O-bound rules run at the same time, 0 means no limit
+//
+// Output is the same as sequential validation
+func WithAsync(ctx context.Context, limit int) context.Context {
+	async := &asyncOptions{limit: limit}
+	if limit > 0 {
+		async.semaphore = make(chan struct{}, limit)
+	}
+	return context.WithValue(ctx, asyncContextKey, async)
+}
+
+// Returns options of async validation which are stored in passed context, nil if async validation is not active
+func asyncFromContext(ctx context.Context) *asyncOptions {
+	if ctx == nil {
+		return nil
+	}
+	async, _ := ctx.Value(asyncContextKey).(*asyncOptions)
+	return async
+}
+
+// Runs passed I/O-bound rule when the limit allows it, returns false if ctx gets done while waiting
+func (o *asyncOptions) run(ctx context.Context, rule func() bool) bool {
+	if o.semaphore != nil {
+		select {
+		case o.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+		defer func() { <-o.semaphore }()
+	}
+	return rule()
+}
+
+// Calls validate for every index from 0 to n-1 concurrently and puts returned errors in output with returned names as keys,
+// errors get merged in order of indexes after all calls are done and nil errors are ignored
+//
+// A panic in one of calls gets raised again in the caller goroutine, calls stop if ctx is done
+func (o *validatorS) validateAll(ctx context.Context, output map[string]interface{}, n int, validate func(i int) (string, interface{})) {
+	names := make([]string, n)
+	outputs := make([]interface{}, n)
+	async := asyncFromContext(ctx)
+	workers := n
+	if async.limit > 0 && async.limit < workers {
+		workers = async.limit
+	}
+	var next int64 = -1
+	var recovered interface{}
+	panicOnce := sync.Once{}
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() { recovered = r })
+				}
+			}()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= n || checkContext(ctx) != nil {
+					return
+				}
+				names[i], outputs[i] = validate(i)
+			}
+		}()
+	}
+	wg.Wait()
+	if recovered != nil {
+		panic(recovered)
+	}
+
+	for i, errors := range outputs {
+		if errors != nil {
+			output[names[i]] = errors
+		}
+	}
+}
+
+// Returns true if fields or elements of input of o get validated concurrently
+func (o *validatorS) isConcurrent(ctx context.Context) bool {
+	return asyncFromContext(ctx) != nil && o.hasAsyncRules()
+}
+
+func (o *validatorS) hasAsyncRules() bool {
+	o.asyncOnce.Do(func() {
+		if o.rule != nil && o.rule.hasAsyncRules() {
+			o.async = true
+		}
+		for _, r := range o.rules {
+			if r != nil && r.hasAsyncRules() {
+				o.async = true
+			}
+		}
+		for _, variant := range o.variants {
+			if variant.hasAsyncRules() {
+				o.async = true
+			}
+		}
+	})
+	return o.async
+}
+
+// Returns keys of rules in sorted order
+func (o *validatorS) ruleKeys() []string {
+	o.keysOnce.Do(func() {
+		o.keys = make([]string, 0, len(o.rules))
+		for key := range o.rules {
+			o.keys = append(o.keys, key)
+		}
+		sort.Strings(o.keys)
+	})
+	return o.keys
+}
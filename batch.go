@@ -0,0 +1,197 @@
+package galidator
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+type (
+	// Configures ValidateBatch method
+	BatchOptions struct {
+		// Number of records which get validated at the same time, default is GOMAXPROCS
+		Workers int
+		// Number of invalid records which are tolerated, validation stops once more records are invalid,
+		// 0 (default) means no limit
+		ErrorBudget int
+		// Translates error messages of records
+		Translator Translator
+	}
+
+	// Output of validation of one record in a batch
+	BatchRecord struct {
+		// Index of the record in passed slice or order of receiving it from passed channel
+		Index int
+		// Output of Validate method for the record, nil if record is valid
+		Errors interface{}
+	}
+
+	// Output of ValidateBatch method
+	BatchResult struct {
+		// Validated records sorted by their index
+		Records []BatchRecord
+		// Number of records in passed slice, or number of records received from passed channel
+		Total int
+		// Number of valid records
+		Valid int
+		// Number of invalid records
+		Invalid int
+		// Number of received records which did not get validated because validation stopped
+		Skipped int
+		// True if validation stopped because more records than ErrorBudget are invalid
+		BudgetExceeded bool
+		// Error of the context if it is done when validation finishes
+		Err error
+	}
+
+	// A record which is waiting for validation
+	batchJob struct {
+		index int
+		input interface{}
+	}
+)
+
+// Returns only invalid records
+func (o *BatchResult) Failed() []BatchRecord {
+	output := []BatchRecord{}
+	for _, record := range o.Records {
+		if record.Errors != nil {
+			output = append(output, record)
+		}
+	}
+	return output
+}
+
+func (o *validatorS) ValidateBatch(ctx context.Context, inputs interface{}, options ...BatchOptions) *BatchResult {
+	opts := BatchOptions{}
+	if len(options) != 0 {
+		opts = options[0]
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	translators := []Translator{}
+	if opts.Translator != nil {
+		translators = append(translators, opts.Translator)
+	}
+
+	inputsValue := reflect.ValueOf(inputs)
+	switch inputsValue.Kind() {
+	case reflect.Slice, reflect.Array:
+	case reflect.Chan:
+		if inputsValue.Type().ChanDir()&reflect.RecvDir == 0 {
+			panic("can not receive records from a send only channel")
+		}
+	default:
+		panic("'inputs' has to be a slice, an array or a channel")
+	}
+
+	stop := make(chan struct{})
+	stopOnce := sync.Once{}
+	stopped := func() bool {
+		select {
+		case <-stop:
+			return true
+		case <-ctx.Done():
+			return true
+		default:
+			return false
+		}
+	}
+
+	jobs := make(chan batchJob)
+	total := 0
+	go func() {
+		defer close(jobs)
+		for i := 0; ; i++ {
+			var input interface{}
+			if inputsValue.Kind() == reflect.Chan {
+				chosen, value, ok := reflect.Select([]reflect.SelectCase{
+					{Dir: reflect.SelectRecv, Chan: inputsValue},
+					{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(stop)},
+					{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+				})
+				if chosen != 0 || !ok {
+					return
+				}
+				input = value.Interface()
+			} else if i < inputsValue.Len() {
+				input = inputsValue.Index(i).Interface()
+			} else {
+				return
+			}
+			total++
+			select {
+			case jobs <- batchJob{index: i, input: input}:
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	records := make(chan BatchRecord)
+	var recovered interface{}
+	panicOnce := sync.Once{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A panic stops validation of other records and gets raised again in the calling goroutine
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() { recovered = r })
+					stopOnce.Do(func() { close(stop) })
+				}
+			}()
+			for job := range jobs {
+				if stopped() {
+					continue
+				}
+				errors := o.Validate(ctx, job.input, translators...)
+				if IsCanceled(errors) {
+					continue
+				}
+				records <- BatchRecord{Index: job.index, Errors: errors}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(records)
+	}()
+
+	output := &BatchResult{Records: []BatchRecord{}}
+	for record := range records {
+		output.Records = append(output.Records, record)
+		if record.Errors == nil {
+			output.Valid++
+			continue
+		}
+		output.Invalid++
+		if opts.ErrorBudget > 0 && output.Invalid > opts.ErrorBudget {
+			output.BudgetExceeded = true
+			stopOnce.Do(func() { close(stop) })
+		}
+	}
+
+	if recovered != nil {
+		panic(recovered)
+	}
+
+	sort.Slice(output.Records, func(i, j int) bool {
+		return output.Records[i].Index < output.Records[j].Index
+	})
+	// Feeder is done here, jobs channel gets closed before records channel
+	output.Total = total
+	if inputsValue.Kind() != reflect.Chan {
+		output.Total = inputsValue.Len()
+	}
+	output.Skipped = output.Total - len(output.Records)
+	output.Err = ctx.Err()
+	return output
+}
@@ -0,0 +1,199 @@
+package galidator
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Message catalogs of languages other than English, English messages are defaultValidatorErrorMessages
+//
+//go:embed catalogs/*.json
+var catalogFiles embed.FS
+
+var (
+	// Registered message catalogs with lower case locales as keys
+	catalogs = map[string]Messages{}
+	// Guards catalogs
+	catalogsMutex sync.RWMutex
+)
+
+// Default locale of error messages
+const DefaultLocale = "en"
+
+func init() {
+	catalogs[DefaultLocale] = DefaultMessages()
+
+	entries, err := catalogFiles.ReadDir("catalogs")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		data, err := catalogFiles.ReadFile(path.Join("catalogs", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		messages := Messages{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(err)
+		}
+		catalogs[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+}
+
+// Adds passed messages to the catalog of passed locale, messages of existing keys get overridden and
+// if there is no catalog for passed locale, a new one gets created
+//
+// Use it to translate messages of custom validators or to change built-in messages of a language
+func RegisterCatalog(locale string, messages Messages) {
+	locale = normalizeLocale(locale)
+	catalogsMutex.Lock()
+	defer catalogsMutex.Unlock()
+	catalog, ok := catalogs[locale]
+	if !ok {
+		catalog = Messages{}
+		catalogs[locale] = catalog
+	}
+	for key, value := range messages {
+		catalog[key] = value
+	}
+}
+
+// Returns a copy of the catalog of passed locale, like catalog of `fa` for `fa-IR`, returns nil if there is no catalog for it
+func Catalog(locale string) Messages {
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	locale, ok := matchLocale(locale)
+	if !ok {
+		return nil
+	}
+	output := Messages{}
+	for key, value := range catalogs[locale] {
+		output[key] = value
+	}
+	return output
+}
+
+// Returns locales which have a catalog, sorted
+func Locales() []string {
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	output := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		output = append(output, locale)
+	}
+	sort.Strings(output)
+	return output
+}
+
+// Returns a translator which translates error messages of every catalog to messages of passed locale
+//
+// Useful to choose the language of error messages per Validate call, messages which are not in any catalog do not change
+func LocaleTranslator(locale string) Translator {
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	target := catalogs[resolveLocale(locale)]
+	translations := map[string]string{}
+	for _, catalog := range catalogs {
+		for key, message := range catalog {
+			if translation, ok := target[key]; ok {
+				translations[message] = translation
+			}
+		}
+	}
+	return func(input string) string {
+		if translation, ok := translations[input]; ok {
+			return translation
+		}
+		return input
+	}
+}
+
+// Returns the registered locale which matches passed `Accept-Language` header best, like `de` for `fr-CH;q=0.5, de-DE`
+//
+// Languages get tried in order of their quality values, returns DefaultLocale if no language has a catalog
+func ParseAcceptLanguage(header string) string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+	languages := []language{}
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" {
+			continue
+		}
+		quality := 1.0
+		for _, field := range fields[1:] {
+			field = strings.TrimSpace(field)
+			if strings.HasPrefix(field, "q=") {
+				if q, err := strconv.ParseFloat(field[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		languages = append(languages, language{tag: tag, quality: quality})
+	}
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	for _, language := range languages {
+		if language.tag == "*" {
+			return DefaultLocale
+		}
+		if locale, ok := matchLocale(language.tag); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// Returns message of passed ruleKey in catalog of passed locale, falls back to the catalog of DefaultLocale
+func catalogMessage(locale string, ruleKey string) (string, bool) {
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	if message, ok := catalogs[resolveLocale(locale)][ruleKey]; ok {
+		return message, true
+	}
+	message, ok := catalogs[DefaultLocale][ruleKey]
+	return message, ok
+}
+
+// Returns the locale which has a catalog for passed locale, like `fa` for `fa-IR`, caller has to hold catalogsMutex
+func resolveLocale(locale string) string {
+	if locale, ok := matchLocale(locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Returns the locale which has a catalog for passed locale and true, returns false if there is no such locale,
+// caller has to hold catalogsMutex
+func matchLocale(locale string) (string, bool) {
+	locale = normalizeLocale(locale)
+	if _, ok := catalogs[locale]; ok {
+		return locale, true
+	}
+	if index := strings.Index(locale, "-"); index != -1 {
+		if _, ok := catalogs[locale[:index]]; ok {
+			return locale[:index], true
+		}
+	}
+	return "", false
+}
+
+// Returns passed locale in lower case with `-` as separator, like `fa-ir` for `fa_IR`
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
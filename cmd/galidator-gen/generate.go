@@ -0,0 +1,1097 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dlclark/regexp2"
+	"github.com/golodash/galidator/v2"
+	"github.com/golodash/galidator/v2/internal/shared"
+	gStrings "github.com/golodash/godash/strings"
+)
+
+// First line of generated files, files which start with it are ignored in parsing
+const generatedHeader = "// Code generated by galidator-gen. DO NOT EDIT."
+
+type (
+	// Type of a value which generated code checks
+	valueType struct {
+		// One of string, bool, int, uint, float, struct and slice
+		kind string
+		// Go type name, like: int64 or Address
+		name string
+		// True if value is a pointer to the type
+		pointer bool
+		// Type of elements if kind is slice
+		elem *valueType
+	}
+
+	// A rule which is parsed from a tag
+	rule struct {
+		// Key of the rule, like: min or len_range
+		key string
+		// Parameters which are separated by & in tag
+		params []string
+		// RuleSets of or and xor rules
+		subs []*ruleSet
+	}
+
+	// Rules of a field or elements of a slice field
+	ruleSet struct {
+		rules []*rule
+		// Specific error messages with snake case of rule keys as keys
+		messages map[string]string
+		// Same as isOptional of ruleSets of galidator, but reversed
+		required        bool
+		allowUnexported bool
+		// Same as sensitive of ruleSets of galidator
+		sensitive bool
+	}
+
+	// A field of a struct with its rules
+	field struct {
+		goName string
+		// Name of the field in error messages
+		name string
+		// Replaces name in `$field` of error messages if it is not empty
+		label    string
+		typ      *valueType
+		rules    *ruleSet
+		children *ruleSet
+	}
+
+	// Generates Validate methods of structs of a package
+	generator struct {
+		// Package level variable which holds galidator generator
+		genExpr string
+		// Struct types of the package with their names as keys
+		structs map[string]*ast.StructType
+		// Names of struct types in order of appearance in source
+		order []string
+		buf   bytes.Buffer
+		// Imports which generated code needs, with paths as keys and aliases as values
+		imports map[string]string
+		// Helper functions which generated code needs
+		helpers map[string]bool
+		// Names of variables of compiled regexes with patterns as keys
+		regexes     map[string]string
+		regexOrder  []string
+		zeroTypes   map[string]bool
+		zeroPending []string
+	}
+)
+
+// Parses go files of passed directory and generates Validate methods of passed types
+//
+// If no types are passed, every struct with g or galidator tags gets generated
+func generate(dir string, typeNames []string, genExpr string) (string, []byte, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+	sort.Strings(paths)
+
+	g := &generator{
+		genExpr:   genExpr,
+		structs:   map[string]*ast.StructType{},
+		imports:   map[string]string{"context": ""},
+		helpers:   map[string]bool{},
+		regexes:   map[string]string{},
+		zeroTypes: map[string]bool{},
+	}
+	pkgName := ""
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(file.Comments) != 0 && file.Comments[0].Pos() < file.Package && strings.HasPrefix(file.Comments[0].Text(), strings.TrimPrefix(generatedHeader, "// ")) {
+			continue
+		}
+		pkgName = file.Name.Name
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+					g.structs[typeSpec.Name.Name] = structType
+					g.order = append(g.order, typeSpec.Name.Name)
+				}
+			}
+		}
+	}
+	if pkgName == "" {
+		return "", nil, fmt.Errorf("no go files found in %s", dir)
+	}
+
+	selected := map[string]bool{}
+	if len(typeNames) == 0 {
+		for _, name := range g.order {
+			if g.hasTags(g.structs[name]) {
+				selected[name] = true
+			}
+		}
+	} else {
+		for _, name := range typeNames {
+			name = strings.TrimSpace(name)
+			if _, ok := g.structs[name]; !ok {
+				return "", nil, fmt.Errorf("struct %s not found", name)
+			}
+			selected[name] = true
+		}
+	}
+	// Nested structs of selected structs get generated too
+	for changed := true; changed; {
+		changed = false
+		for _, name := range g.order {
+			if !selected[name] {
+				continue
+			}
+			for _, f := range g.structs[name].Fields.List {
+				if nested := g.localStruct(f.Type); nested != "" && !selected[nested] {
+					selected[nested] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	for _, name := range g.order {
+		if selected[name] {
+			if err := g.emitType(name); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	for len(g.zeroPending) != 0 {
+		name := g.zeroPending[0]
+		g.zeroPending = g.zeroPending[1:]
+		if err := g.emitIsZero(name); err != nil {
+			return "", nil, err
+		}
+	}
+
+	source, err := format.Source(g.file(pkgName))
+	if err != nil {
+		return "", nil, err
+	}
+	return pkgName, source, nil
+}
+
+// Returns true if at least one field of passed struct or its embedded structs has g or galidator tag
+func (g *generator) hasTags(structType *ast.StructType) bool {
+	for _, f := range structType.Fields.List {
+		tag := fieldTag(f)
+		if tag.Get("g") != "" || tag.Get("galidator") != "" {
+			return true
+		}
+		if embedded := g.localStruct(f.Type); len(f.Names) == 0 && embedded != "" && g.structs[embedded] != structType && g.hasTags(g.structs[embedded]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns tag of passed field
+func fieldTag(f *ast.Field) reflect.StructTag {
+	if f.Tag == nil {
+		return ""
+	}
+	tag, _ := strconv.Unquote(f.Tag.Value)
+	return reflect.StructTag(tag)
+}
+
+// Returns name of the struct of the package which passed type refers to, like: T, *T, []T or []*T
+func (g *generator) localStruct(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return g.localStruct(e.X)
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return g.localStruct(e.Elt)
+		}
+	case *ast.Ident:
+		if _, ok := g.structs[e.Name]; ok {
+			return e.Name
+		}
+	}
+	return ""
+}
+
+// Returns type of a value which is supported in generated code
+func (g *generator) resolve(expr ast.Expr) (*valueType, error) {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		t, err := g.resolve(e.X)
+		if err != nil {
+			return nil, err
+		}
+		if t.pointer || t.kind == "slice" {
+			return nil, fmt.Errorf("pointers to %s are not supported", typeString(e.X))
+		}
+		t.pointer = true
+		return t, nil
+	case *ast.ArrayType:
+		if e.Len != nil {
+			return nil, fmt.Errorf("arrays are not supported")
+		}
+		elem, err := g.resolve(e.Elt)
+		if err != nil {
+			return nil, err
+		}
+		if elem.kind == "slice" {
+			return nil, fmt.Errorf("slices of slices are not supported")
+		}
+		return &valueType{kind: "slice", name: "[]" + typeString(e.Elt), elem: elem}, nil
+	case *ast.Ident:
+		switch e.Name {
+		case "string", "bool":
+			return &valueType{kind: e.Name, name: e.Name}, nil
+		case "rune":
+			return &valueType{kind: "int", name: "int32"}, nil
+		case "byte":
+			return &valueType{kind: "uint", name: "uint8"}, nil
+		case "int", "int8", "int16", "int32", "int64":
+			return &valueType{kind: "int", name: e.Name}, nil
+		case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+			return &valueType{kind: "uint", name: e.Name}, nil
+		case "float32", "float64":
+			return &valueType{kind: "float", name: e.Name}, nil
+		}
+		if _, ok := g.structs[e.Name]; ok {
+			return &valueType{kind: "struct", name: e.Name}, nil
+		}
+	}
+	return nil, fmt.Errorf("type %s is not supported", typeString(expr))
+}
+
+// Returns passed type as it is written in source
+func typeString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	format.Node(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+// Returns fields of passed struct with their parsed rules
+func (g *generator) fields(structName string) ([]*field, error) {
+	fields := []*field{}
+	for _, f := range g.structs[structName].Fields.List {
+		tag := fieldTag(f)
+		tagged := tag.Get("g") != "" || tag.Get("galidator") != ""
+		if len(f.Names) == 0 {
+			if tagged || g.localStruct(f.Type) != "" {
+				return nil, fmt.Errorf("%s: embedded field %s is not supported", structName, typeString(f.Type))
+			}
+			continue
+		}
+
+		for _, ident := range f.Names {
+			if ident.Name == "_" {
+				continue
+			}
+			out := &field{goName: ident.Name, name: ident.Name, rules: &ruleSet{messages: map[string]string{}}}
+			if jsonName := tag.Get("json"); jsonName != "" {
+				out.name = jsonName
+			}
+			out.label = tag.Get("label")
+			typ, err := g.resolve(f.Type)
+			if err != nil {
+				if tagged {
+					return nil, fmt.Errorf("%s.%s: %s", structName, ident.Name, err)
+				}
+				// Nothing to check
+				continue
+			}
+			out.typ = typ
+			if typ.kind == "slice" && typ.elem.kind != "struct" {
+				// Same as the ruleSet which galidator sets as children of slices
+				out.children = &ruleSet{rules: []*rule{{key: "type", params: []string{typ.elem.name}}}, messages: map[string]string{}}
+			}
+
+			for _, fullTag := range []string{tag.Get("g"), tag.Get("galidator")} {
+				for _, filter := range strings.Split(fullTag, ",") {
+					if index := strings.LastIndex(filter, "@"); index != -1 && shared.GroupsPattern.MatchString(filter[index+1:]) {
+						return nil, fmt.Errorf("%s.%s: validation groups are not supported", structName, ident.Name)
+					}
+					parts := strings.SplitN(filter, "=", 2)
+					normalFuncName, err := g.applyRule(out, out.rules, parts, true)
+					if err != nil {
+						return nil, fmt.Errorf("%s.%s: %s", structName, ident.Name, err)
+					}
+					message := tag.Get("_" + normalFuncName)
+					if message == "" {
+						message = tag.Get(normalFuncName)
+					}
+					addSpecificMessage(out, out.rules, normalFuncName, message)
+				}
+			}
+
+			// Unexported fields are ignored, like galidator does
+			if !ast.IsExported(ident.Name) && !out.rules.allowUnexported {
+				continue
+			}
+			fields = append(fields, out)
+		}
+	}
+
+	return fields, nil
+}
+
+// Adds passed message as specific message of rule with passed name, like addSpecificMessage of galidator
+func addSpecificMessage(f *field, rs *ruleSet, funcName, message string) {
+	splits := strings.SplitN(funcName, ".", 2)
+	if first := gStrings.SnakeCase(splits[0]); len(splits) > 1 && (first == "c" || first == "child") {
+		addSpecificMessage(f, f.children, splits[1], message)
+		return
+	}
+	if message != "" {
+		rs.messages[gStrings.SnakeCase(funcName)] = message
+	}
+}
+
+// Adds passed rule to passed ruleSet, replaces it if a rule with the same key exists
+func (rs *ruleSet) add(r *rule) {
+	for i, existing := range rs.rules {
+		if existing.key == r.key {
+			rs.rules[i] = r
+			return
+		}
+	}
+	rs.rules = append(rs.rules, r)
+}
+
+// Parses rule of passed tag part into passed ruleSet, like applyRules of galidator
+func (g *generator) applyRule(f *field, rs *ruleSet, tag []string, orXor bool) (string, error) {
+	normalFuncName := strings.TrimSpace(tag[0])
+	splits := strings.SplitN(normalFuncName, ".", 2)
+	if first := gStrings.SnakeCase(splits[0]); len(splits) > 1 && (first == "c" || first == "child") {
+		if f == nil {
+			return "", fmt.Errorf("children rules inside or and xor rules are not supported")
+		}
+		if f.children == nil {
+			return "", fmt.Errorf("%s rule needs a slice of values which are not structs", normalFuncName)
+		}
+		_, err := g.applyRule(f, f.children, append([]string{splits[1]}, tag[1:]...), orXor)
+		return normalFuncName, err
+	} else if len(splits) > 1 && (first == "then" || first == "otherwise") {
+		return "", fmt.Errorf("when conditions are not supported")
+	} else if len(splits) > 1 {
+		return "", fmt.Errorf("can't understand %s rule", normalFuncName)
+	}
+
+	parameters := []string{}
+	if len(tag) == 2 {
+		parameters = strings.Split(tag[1], "&")
+	}
+
+	switch funcName := gStrings.PascalCase(normalFuncName); funcName {
+	case "Int", "Float", "String", "Map", "Slice", "Struct", "Email", "Phone", "Password":
+		rs.add(&rule{key: gStrings.SnakeCase(funcName)})
+	case "Min", "Max":
+		if len(parameters) == 1 {
+			if _, err := strconv.ParseFloat(parameters[0], 64); err == nil {
+				rs.add(&rule{key: gStrings.SnakeCase(funcName), params: parameters})
+			}
+		}
+	case "LenRange":
+		if len(parameters) == 2 {
+			if _, err := strconv.ParseInt(parameters[0], 10, 64); err == nil {
+				if _, err := strconv.ParseInt(parameters[1], 10, 64); err == nil {
+					rs.add(&rule{key: "len_range", params: parameters})
+				}
+			}
+		}
+	case "Len":
+		if len(parameters) == 1 {
+			if _, err := strconv.ParseInt(parameters[0], 10, 64); err == nil {
+				rs.add(&rule{key: "len", params: parameters})
+			}
+		}
+	case "Required", "NonZero", "NonNil", "NonEmpty":
+		rs.add(&rule{key: gStrings.SnakeCase(funcName)})
+		rs.required = true
+	case "Optional":
+		rs.required = false
+	case "Regex":
+		if len(tag) == 2 {
+			if _, err := regexp2.Compile(tag[1], regexp2.None); err != nil {
+				return "", fmt.Errorf("invalid regex pattern %q: %s", tag[1], err)
+			}
+			rs.add(&rule{key: "regex", params: tag[1:]})
+		}
+	case "Or", "Xor":
+		if !orXor {
+			return "", fmt.Errorf("OR or XOR inside another OR or XOR is not possible")
+		}
+		if len(tag) != 2 {
+			return "", fmt.Errorf("%s rule needs ruleSets", normalFuncName)
+		}
+		r := &rule{key: gStrings.SnakeCase(funcName)}
+		for _, parameters := range strings.Split(tag[1], "|") {
+			sub := &ruleSet{messages: map[string]string{}}
+			for _, p := range strings.Split(parameters, "+") {
+				if _, err := g.applyRule(nil, sub, strings.SplitN(p, "=", 2), false); err != nil {
+					return "", err
+				}
+			}
+			r.subs = append(r.subs, sub)
+		}
+		rs.add(r)
+	case "Choices":
+		rs.add(&rule{key: "choices", params: parameters})
+	case "WhenExistOne", "WhenExistAll", "WhenNotExistOne", "WhenNotExistAll":
+		rs.add(&rule{key: gStrings.SnakeCase(funcName), params: parameters})
+	case "AllowUnexported":
+		rs.allowUnexported = true
+	case "Sensitive":
+		rs.sensitive = true
+	case "Nested":
+		// Only changes how an embedded struct is treated
+	case "When":
+		return "", fmt.Errorf("when conditions are not supported")
+	case "Children", "Custom", "Complex", "Type":
+		return "", fmt.Errorf("take a look at documentations, %s rule does not work in tags like this", funcName)
+	default:
+		if normalFuncName != "" {
+			if g.genExpr == "" {
+				return "", fmt.Errorf("%s custom validator needs -generator flag", normalFuncName)
+			}
+			if len(parameters) != 0 {
+				return "", fmt.Errorf("parameters of %s custom rule are not supported", normalFuncName)
+			}
+			rs.add(&rule{key: normalFuncName})
+		}
+	}
+
+	return normalFuncName, nil
+}
+
+// Writes formatted code into the buffer
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// Writes Validate method of passed struct
+func (g *generator) emitType(structName string) error {
+	fields, err := g.fields(structName)
+	if err != nil {
+		return err
+	}
+	byName := map[string]*field{}
+	for _, f := range fields {
+		byName[f.goName] = f
+	}
+
+	g.printf("// Validate validates x like a galidator validator which is generated from %s, returns nil if no errors found\n", structName)
+	g.printf("func (x *%s) Validate(ctx context.Context) map[string]interface{} {\n", structName)
+	g.printf("output := map[string]interface{}{}\n")
+	for _, f := range fields {
+		if err := g.emitField(structName, f); err != nil {
+			return fmt.Errorf("%s.%s: %s", structName, f.goName, err)
+		}
+	}
+	g.printf("if len(output) == 0 {\nreturn nil\n}\nreturn output\n}\n\n")
+	return nil
+}
+
+// Writes validation of passed field
+func (g *generator) emitField(structName string, f *field) error {
+	// Like reflection based validation, fields which are pointers to structs get validated deeply if they are not nil
+	nested := f.typ.kind == "struct" || f.typ.kind == "slice"
+	if len(f.rules.rules) == 0 && !nested {
+		// Nothing to check
+		return nil
+	}
+	if f.rules.sensitive && nested {
+		if f.typ.kind == "struct" || f.typ.elem.kind == "struct" {
+			return fmt.Errorf("sensitive rule is not supported on nested structs, their Validate methods show values")
+		}
+		// Like galidator, elements of a sensitive slice are sensitive too
+		f.children.sensitive = true
+	}
+	g.printf("// %s\n{\nvalue := x.%s\n", f.goName, f.goName)
+
+	skip, err := g.skipExpr(structName, f.rules, f.typ, "value", true)
+	if err != nil {
+		return err
+	}
+	if skip != "false" {
+		g.printf("if !(%s) {\n", skip)
+	}
+
+	if len(f.rules.rules) != 0 {
+		if err := g.emitChecks(f.rules, f.typ, "value", f.name, f.label, "errors"); err != nil {
+			return err
+		}
+		g.printf("if len(errors) != 0 {\noutput[%q] = errors\n}", f.name)
+		if nested {
+			g.printf(" else {\n")
+		} else {
+			g.printf("\n")
+		}
+	}
+
+	switch {
+	case f.typ.kind == "struct" && f.typ.pointer:
+		g.printf("if value != nil {\nif errors := value.Validate(ctx); len(errors) != 0 {\noutput[%q] = errors\n}\n}\n", f.name)
+	case f.typ.kind == "struct":
+		g.printf("if errors := value.Validate(ctx); len(errors) != 0 {\noutput[%q] = errors\n}\n", f.name)
+	case f.typ.kind == "slice":
+		g.imports["strconv"] = ""
+		g.printf("children := map[string]interface{}{}\nfor i := range value {\n")
+		elem := f.typ.elem
+		if elem.kind == "struct" && !elem.pointer {
+			g.printf("element := &value[i]\n")
+		} else {
+			g.printf("element := value[i]\n")
+		}
+		if elem.kind == "struct" {
+			if elem.pointer {
+				g.helpers["message"] = true
+				g.printf("if element == nil {\nchildren[strconv.Itoa(i)] = []string{galidatorMessage(ctx, \"non_nil\", \"\", \"\", \"\", nil)}\n} else ")
+			}
+			g.printf("if errors := element.Validate(ctx); len(errors) != 0 {\nchildren[strconv.Itoa(i)] = errors\n}\n")
+		} else {
+			skip, err := g.skipExpr(structName, f.children, elem, "element", false)
+			if err != nil {
+				return err
+			}
+			if skip != "false" {
+				g.printf("if !(%s) {\n", skip)
+			}
+			if err := g.emitChecks(f.children, elem, "element", "", "", "errors"); err != nil {
+				return err
+			}
+			g.printf("if len(errors) != 0 {\nchildren[strconv.Itoa(i)] = errors\n}\n")
+			if skip != "false" {
+				g.printf("}\n")
+			}
+		}
+		g.printf("}\nif len(children) != 0 {\noutput[%q] = children\n}\n", f.name)
+	}
+
+	if len(f.rules.rules) != 0 && nested {
+		g.printf("}\n")
+	}
+	if skip != "false" {
+		g.printf("}\n")
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// Returns an expression which is true if passed value has to be skipped, like validateField of galidator
+//
+// Requires are only checked on fields of structs, like galidator does
+func (g *generator) skipExpr(structName string, rs *ruleSet, typ *valueType, raw string, checkRequires bool) (string, error) {
+	if rs.required {
+		return "false", nil
+	}
+	isEmpty, err := g.emptyExpr(raw, typ)
+	if err != nil {
+		return "", err
+	}
+
+	requires := []string{}
+	for _, r := range rs.rules {
+		if !checkRequires || !strings.HasPrefix(r.key, "when_") {
+			continue
+		}
+		fieldsEmpty := []string{}
+		for _, name := range r.params {
+			expr, err := g.fieldEmptyExpr(structName, name)
+			if err != nil {
+				return "", err
+			}
+			fieldsEmpty = append(fieldsEmpty, expr)
+		}
+
+		// Expressions are true when the require makes the value required
+		switch r.key {
+		case "when_exist_one":
+			requires = append(requires, fmt.Sprintf("(%s && %s)", isEmpty, joinExpr(negateAll(fieldsEmpty), " || ", "false")))
+		case "when_exist_all":
+			requires = append(requires, fmt.Sprintf("(%s && %s)", joinExpr(negateAll(fieldsEmpty), " && ", "true"), isEmpty))
+		case "when_not_exist_one":
+			requires = append(requires, fmt.Sprintf("(%s && %s)", isEmpty, joinExpr(fieldsEmpty, " || ", "false")))
+		case "when_not_exist_all":
+			requires = append(requires, fmt.Sprintf("(%s && %s)", joinExpr(fieldsEmpty, " && ", "true"), isEmpty))
+		}
+	}
+
+	if len(requires) == 0 {
+		return isEmpty, nil
+	}
+	return fmt.Sprintf("!(%s) && %s", strings.Join(requires, " && "), isEmpty), nil
+}
+
+// Returns an expression which is true if passed field of the struct is empty, nil or zero
+func (g *generator) fieldEmptyExpr(structName, goName string) (string, error) {
+	for _, f := range g.structs[structName].Fields.List {
+		for _, ident := range f.Names {
+			if ident.Name != goName {
+				continue
+			}
+			typ, err := g.resolve(f.Type)
+			if err != nil {
+				return "", fmt.Errorf("%s field: %s", goName, err)
+			}
+			return g.emptyExpr("x."+goName, typ)
+		}
+	}
+	return "", fmt.Errorf("%s field not found", goName)
+}
+
+// Returns an expression which is true if passed value is empty, nil or zero, like isEmptyNilZero of galidator
+func (g *generator) emptyExpr(raw string, typ *valueType) (string, error) {
+	if typ.pointer {
+		return raw + " == nil", nil
+	}
+	switch typ.kind {
+	case "int", "uint", "float":
+		// Numbers are never empty
+		return "false", nil
+	case "string":
+		return raw + ` == ""`, nil
+	case "bool":
+		return "!" + raw, nil
+	case "slice":
+		return "len(" + raw + ") == 0", nil
+	default:
+		g.needZero(typ.name)
+		return raw + ".galidatorIsZero()", nil
+	}
+}
+
+// Writes checks of rules of passed ruleSet which append error messages to a slice called errorsName
+//
+// label of the field gets translated like galidator does when messages are formatted
+func (g *generator) emitChecks(rs *ruleSet, typ *valueType, raw, name, label, errorsName string) error {
+	// Values of sensitive fields do not appear in messages
+	value := "current"
+	if rs.sensitive {
+		value = strconv.Quote(galidator.RedactedValue)
+	}
+	checks := []string{}
+	for _, r := range rs.rules {
+		pass, err := g.passExpr(r, typ, raw)
+		if err != nil {
+			return err
+		} else if pass == "true" {
+			continue
+		}
+		args := []string{"ctx", strconv.Quote(r.key), strconv.Quote(rs.messages[gStrings.SnakeCase(r.key)]), strconv.Quote(name), strconv.Quote(label), value}
+		for _, option := range options(r) {
+			args = append(args, strconv.Quote(option))
+		}
+		g.helpers["message"] = true
+		checks = append(checks, fmt.Sprintf("if !(%s) {\n%s = append(%s, galidatorMessage(%s))\n}\n", pass, errorsName, errorsName, strings.Join(args, ", ")))
+	}
+
+	if !rs.sensitive || strings.Contains(strings.Join(checks, ""), "(ctx, current)") {
+		if typ.pointer {
+			g.printf("var current interface{}\nif %s != nil {\ncurrent = *%s\n}\n", raw, raw)
+		} else {
+			g.printf("var current interface{} = %s\n", raw)
+		}
+	}
+	g.printf("%s := []string{}\n", errorsName)
+	for _, check := range checks {
+		g.printf("%s", check)
+	}
+	return nil
+}
+
+// Returns options of passed rule as key and value pairs which replace $key in error messages
+func options(r *rule) []string {
+	switch r.key {
+	case "min", "max":
+		number, _ := strconv.ParseFloat(r.params[0], 64)
+		return []string{r.key, fmt.Sprintf("%."+shared.DeterminePrecision(number)+"f", number)}
+	case "len_range":
+		from, _ := strconv.ParseInt(r.params[0], 10, 64)
+		to, _ := strconv.ParseInt(r.params[1], 10, 64)
+		return []string{"from", fmt.Sprintf("%d", from), "to", fmt.Sprintf("%d", to)}
+	case "len":
+		length, _ := strconv.ParseInt(r.params[0], 10, 64)
+		return []string{"length", fmt.Sprint(int(length))}
+	case "regex":
+		return []string{"pattern", r.params[0]}
+	case "type":
+		return []string{"type", r.params[0]}
+	case "choices", "when_exist_one", "when_exist_all", "when_not_exist_one", "when_not_exist_all":
+		return []string{"choices", strings.ReplaceAll(fmt.Sprint(r.params), " ", ", ")}
+	}
+	return nil
+}
+
+// Returns an expression which is true if passed value passes passed rule
+func (g *generator) passExpr(r *rule, typ *valueType, raw string) (string, error) {
+	value := raw
+	notNil := ""
+	if typ.pointer {
+		value = "*" + raw
+		notNil = raw + " != nil"
+	}
+	isString := typ.kind == "string"
+	isNumber := typ.kind == "int" || typ.kind == "uint" || typ.kind == "float"
+	hasLen := isString || typ.kind == "slice"
+
+	switch r.key {
+	case "required", "when_exist_one", "when_exist_all", "when_not_exist_one", "when_not_exist_all":
+		switch {
+		case isNumber:
+			return and(notNil, "true"), nil
+		case typ.kind == "bool":
+			return and(notNil, value), nil
+		case typ.kind == "struct":
+			g.needZero(typ.name)
+			return and(notNil, "!"+raw+".galidatorIsZero()"), nil
+		case isString:
+			return and(notNil, value+` != ""`), nil
+		default:
+			return and(notNil, "len("+value+") != 0"), nil
+		}
+	case "non_zero":
+		expr := ""
+		switch {
+		case isNumber:
+			expr = value + " != 0"
+		case typ.kind == "bool":
+			expr = value
+		case typ.kind == "struct":
+			g.needZero(typ.name)
+			expr = "!" + raw + ".galidatorIsZero()"
+		case isString:
+			expr = value + ` != ""`
+		default:
+			expr = value + " != nil"
+		}
+		if typ.pointer {
+			return raw + " == nil || " + expr, nil
+		}
+		return expr, nil
+	case "non_nil":
+		return and(notNil, "true"), nil
+	case "non_empty":
+		if typ.kind == "slice" {
+			return "len(" + value + ") != 0", nil
+		}
+		return "true", nil
+	case "int":
+		return and(notNil, fmt.Sprint(typ.kind == "int" || typ.kind == "uint")), nil
+	case "float", "string", "slice", "struct":
+		return and(notNil, fmt.Sprint(typ.kind == r.key)), nil
+	case "map":
+		return "false", nil
+	case "type":
+		return and(notNil, "true"), nil
+	case "min", "max":
+		operator := ">="
+		if r.key == "max" {
+			operator = "<="
+		}
+		number, _ := strconv.ParseFloat(r.params[0], 64)
+		if hasLen {
+			return and(notNil, fmt.Sprintf("len(%s) %s %d", value, operator, int(number))), nil
+		} else if isNumber {
+			return and(notNil, fmt.Sprintf("float64(%s) %s %s", value, operator, strconv.FormatFloat(number, 'g', -1, 64))), nil
+		}
+		return "false", nil
+	case "len_range":
+		if !hasLen {
+			return "false", nil
+		}
+		from, _ := strconv.Atoi(r.params[0])
+		to, _ := strconv.Atoi(r.params[1])
+		conditions := []string{}
+		if from != -1 {
+			conditions = append(conditions, fmt.Sprintf("len(%s) >= %d", value, from))
+		}
+		if to != -1 {
+			conditions = append(conditions, fmt.Sprintf("len(%s) <= %d", value, to))
+		}
+		return and(notNil, joinExpr(conditions, " && ", "true")), nil
+	case "len":
+		if !hasLen {
+			return "false", nil
+		}
+		length, _ := strconv.Atoi(r.params[0])
+		return and(notNil, fmt.Sprintf("len(%s) == %d", value, length)), nil
+	case "email":
+		if !isString {
+			return "false", nil
+		}
+		g.helpers["email"] = true
+		return and(notNil, "galidatorEmail("+value+")"), nil
+	case "phone":
+		if !isString {
+			return "", fmt.Errorf("phone rule needs a string")
+		}
+		g.helpers["phone"] = true
+		return and(notNil, "galidatorPhone("+value+")"), nil
+	case "regex", "password":
+		if !isString {
+			return "false", nil
+		}
+		pattern := shared.PasswordPattern
+		if r.key == "regex" {
+			pattern = r.params[0]
+		}
+		return and(notNil, fmt.Sprintf("galidatorMatch(%s, %s)", g.regex(pattern), value)), nil
+	case "choices":
+		if !isString {
+			return "false", nil
+		}
+		choices := []string{}
+		for _, choice := range r.params {
+			choices = append(choices, fmt.Sprintf("%s == %s", value, strconv.Quote(choice)))
+		}
+		return and(notNil, joinExpr(choices, " || ", "false")), nil
+	case "or", "xor":
+		subs := []string{}
+		for _, sub := range r.subs {
+			passes := []string{}
+			for _, subRule := range sub.rules {
+				pass, err := g.passExpr(subRule, typ, raw)
+				if err != nil {
+					return "", err
+				}
+				passes = append(passes, "("+pass+")")
+			}
+			subs = append(subs, joinExpr(passes, " && ", "true"))
+		}
+		if r.key == "or" {
+			return joinExpr(subs, " || ", "true"), nil
+		}
+		output := "false"
+		for _, sub := range subs {
+			output = fmt.Sprintf("((%s) != (%s))", sub, output)
+		}
+		return output, nil
+	default:
+		g.helpers["custom"] = true
+		return fmt.Sprintf("galidatorCustom(%q)(ctx, current)", r.key), nil
+	}
+}
+
+// Joins passed expressions with passed operator in parentheses, returns empty if there is no expression
+func joinExpr(expressions []string, operator, empty string) string {
+	if len(expressions) == 0 {
+		return empty
+	}
+	return "(" + strings.Join(expressions, operator) + ")"
+}
+
+// Returns negated forms of passed expressions
+func negateAll(expressions []string) []string {
+	output := []string{}
+	for _, expression := range expressions {
+		output = append(output, "!("+expression+")")
+	}
+	return output
+}
+
+// Returns an expression which is true if both passed expressions are true, empty first expression counts as true
+func and(first, second string) string {
+	if first == "" {
+		return second
+	} else if second == "true" {
+		return first
+	} else if second == "false" {
+		return "false"
+	}
+	return first + " && " + second
+}
+
+// Returns name of the variable which holds compiled form of passed pattern
+func (g *generator) regex(pattern string) string {
+	if name, ok := g.regexes[pattern]; ok {
+		return name
+	}
+	name := fmt.Sprintf("galidatorRegex%d", len(g.regexOrder))
+	g.regexes[pattern] = name
+	g.regexOrder = append(g.regexOrder, pattern)
+	g.helpers["match"] = true
+	return name
+}
+
+// Marks passed struct as a struct which needs galidatorIsZero method
+func (g *generator) needZero(structName string) {
+	if !g.zeroTypes[structName] {
+		g.zeroTypes[structName] = true
+		g.zeroPending = append(g.zeroPending, structName)
+	}
+}
+
+// Writes galidatorIsZero method of passed struct
+func (g *generator) emitIsZero(structName string) error {
+	conditions := []string{}
+	for _, f := range g.structs[structName].Fields.List {
+		names := []string{}
+		for _, ident := range f.Names {
+			names = append(names, ident.Name)
+		}
+		if len(f.Names) == 0 {
+			expr := f.Type
+			if star, ok := expr.(*ast.StarExpr); ok {
+				expr = star.X
+			}
+			if selector, ok := expr.(*ast.SelectorExpr); ok {
+				expr = selector.Sel
+			}
+			names = append(names, typeString(expr))
+		}
+
+		for _, name := range names {
+			if name == "_" {
+				continue
+			}
+			condition := ""
+			switch t := f.Type.(type) {
+			case *ast.StarExpr, *ast.MapType, *ast.FuncType, *ast.ChanType, *ast.InterfaceType:
+				condition = "x." + name + " == nil"
+			case *ast.ArrayType:
+				if t.Len != nil {
+					return fmt.Errorf("%s.%s: can not check if arrays are zero", structName, name)
+				}
+				condition = "x." + name + " == nil"
+			case *ast.Ident:
+				typ, err := g.resolve(t)
+				switch {
+				case t.Name == "error" || t.Name == "any":
+					condition = "x." + name + " == nil"
+				case err != nil:
+					return fmt.Errorf("%s.%s: can not check if %s is zero", structName, name, t.Name)
+				case typ.kind == "struct":
+					g.needZero(typ.name)
+					condition = "x." + name + ".galidatorIsZero()"
+				case typ.kind == "string":
+					condition = "x." + name + ` == ""`
+				case typ.kind == "bool":
+					condition = "!x." + name
+				default:
+					condition = "x." + name + " == 0"
+				}
+			default:
+				return fmt.Errorf("%s.%s: can not check if %s is zero", structName, name, typeString(f.Type))
+			}
+			conditions = append(conditions, condition)
+		}
+	}
+
+	g.printf("// galidatorIsZero returns true if x is the zero value of %s\n", structName)
+	g.printf("func (x *%s) galidatorIsZero() bool {\nreturn %s\n}\n\n", structName, joinExpr(conditions, " && ", "true"))
+	return nil
+}
+
+// Returns content of generated file
+func (g *generator) file(pkgName string) []byte {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\npackage %s\n\n", generatedHeader, pkgName)
+
+	if g.helpers["message"] {
+		g.imports["github.com/golodash/galidator/v2"] = ""
+	}
+	if g.helpers["custom"] {
+		g.imports["fmt"] = ""
+	}
+	if g.helpers["email"] {
+		g.imports["net/mail"] = ""
+	}
+	if g.helpers["phone"] {
+		g.imports["github.com/nyaruka/phonenumbers"] = ""
+		g.regex(`^\+\d+$`)
+	}
+	if g.helpers["match"] {
+		g.imports["github.com/dlclark/regexp2"] = ""
+	}
+	standard, others := []string{}, []string{}
+	for path := range g.imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			others = append(others, path)
+		} else {
+			standard = append(standard, path)
+		}
+	}
+	sort.Strings(standard)
+	sort.Strings(others)
+	out.WriteString("import (\n")
+	for _, path := range standard {
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+	if len(standard) != 0 && len(others) != 0 {
+		out.WriteString("\n")
+	}
+	for _, path := range others {
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+	out.WriteString(")\n\n")
+
+	if len(g.regexOrder) != 0 {
+		out.WriteString("var (\n")
+		for _, pattern := range g.regexOrder {
+			fmt.Fprintf(&out, "%s = regexp2.MustCompile(%s, regexp2.None)\n", g.regexes[pattern], quote(pattern))
+		}
+		out.WriteString(")\n\n")
+	}
+
+	out.Write(g.buf.Bytes())
+
+	if g.helpers["message"] {
+		// Messages get formatted by galidator, so catalogs, message functions and templates work like they do in validators
+		format := "galidator.FormatMessage"
+		if g.genExpr != "" {
+			format = g.genExpr + ".FormatMessage"
+		}
+		out.WriteString("// Formats error message of passed rule key like galidator does\n")
+		out.WriteString("func galidatorMessage(ctx context.Context, key, message, field, label string, value interface{}, options ...string) string {\n")
+		out.WriteString("params := map[string]string{}\nfor i := 0; i+1 < len(options); i += 2 {\nparams[options[i]] = options[i+1]\n}\n")
+		fmt.Fprintf(&out, "return %s(ctx, galidator.GeneratedMessage{Key: key, Message: message, Field: field, Label: label, Value: value, Options: params})\n}\n\n", format)
+	}
+	if g.helpers["custom"] {
+		out.WriteString("// Returns custom validator which is registered with passed key in the generator\n")
+		out.WriteString("func galidatorCustom(key string) func(context.Context, interface{}) bool {\n")
+		fmt.Fprintf(&out, "if validator := %s.GetCustomValidator(key); validator != nil {\nreturn validator\n}\n", g.genExpr)
+		out.WriteString("panic(fmt.Sprintf(\"%s custom validator did not find, call CustomValidators function before calling Validator function\", key))\n}\n\n")
+	}
+	if g.helpers["email"] {
+		out.WriteString("// Returns true if input is a valid email\n")
+		out.WriteString("func galidatorEmail(input string) bool {\n_, err := mail.ParseAddress(input)\nreturn err == nil\n}\n\n")
+	}
+	if g.helpers["phone"] {
+		out.WriteString("// Returns true if input is a valid phone number\n")
+		fmt.Fprintf(&out, "func galidatorPhone(input string) bool {\nif !galidatorMatch(%s, input) {\nreturn false\n}\n", g.regexes[`^\+\d+$`])
+		out.WriteString("parsedNumber, err := phonenumbers.Parse(input, \"\")\nreturn err == nil && phonenumbers.IsValidNumber(parsedNumber)\n}\n\n")
+	}
+	if g.helpers["match"] {
+		out.WriteString("// Returns true if input matches passed regex\n")
+		out.WriteString("func galidatorMatch(regex *regexp2.Regexp, input string) bool {\noutput, _ := regex.MatchString(input)\nreturn output\n}\n")
+	}
+
+	return out.Bytes()
+}
+
+// Returns passed string as a Go string literal, raw literals are preferred for patterns
+func quote(s string) string {
+	if !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
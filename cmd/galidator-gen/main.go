@@ -0,0 +1,55 @@
+// Command galidator-gen generates reflection free `Validate(ctx) map[string]interface{}` methods
+// for structs which have `g` or `galidator` tags.
+//
+// Usage:
+//
+//	galidator-gen [-type User,Order] [-generator G] [-output types_galidator.go] [directory]
+//
+// Generated methods return the same output as a validator which galidator generates from the same struct at runtime.
+//
+// -generator is a package level variable which holds a galidator generator, it is used for custom validators and
+// custom error messages, without it custom validators can not be used in tags.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	types := flag.String("type", "", "comma separated list of struct names, default is every struct with g or galidator tags")
+	generator := flag.String("generator", "", "package level variable which holds the generator of custom validators and messages")
+	output := flag.String("output", "", "output file name, default is <package>_galidator.go in the directory")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	typeNames := []string{}
+	if *types != "" {
+		typeNames = strings.Split(*types, ",")
+	}
+
+	pkgName, source, err := generate(dir, typeNames, *generator)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "galidator-gen:", err)
+		os.Exit(1)
+	}
+
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = pkgName + "_galidator.go"
+	}
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(dir, outputPath)
+	}
+	if err := os.WriteFile(outputPath, source, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "galidator-gen:", err)
+		os.Exit(1)
+	}
+}
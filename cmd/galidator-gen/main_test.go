@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedIsUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "tests", "generated")
+	_, source, err := generate(dir, nil, "G")
+	if err != nil {
+		t.Fatal(err)
+	}
+	committed, err := os.ReadFile(filepath.Join(dir, "types_galidator.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(source, committed) {
+		t.Errorf("tests/generated/types_galidator.go is outdated, run go generate ./tests/generated")
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		source   string
+		genExpr  string
+		expected string
+	}{
+		{
+			name:     "groups",
+			source:   "type T struct {\nA string `g:\"required@create\"`\n}",
+			expected: "validation groups are not supported",
+		},
+		{
+			name:     "when",
+			source:   "type T struct {\nA string `g:\"when=B&x,then.required\"`\nB string\n}",
+			expected: "when conditions are not supported",
+		},
+		{
+			name:     "custom-without-generator",
+			source:   "type T struct {\nA string `g:\"custom_rule\"`\n}",
+			expected: "custom_rule custom validator needs -generator flag",
+		},
+		{
+			name:     "map",
+			source:   "type T struct {\nA map[string]string `g:\"required\"`\n}",
+			expected: "type map[string]string is not supported",
+		},
+		{
+			name:     "embedded",
+			source:   "type E struct {\nA string `g:\"required\"`\n}\ntype T struct {\nE\n}",
+			expected: "embedded field E is not supported",
+		},
+		{
+			name:     "sensitive-struct",
+			source:   "type E struct {\nA string `g:\"required\"`\n}\ntype T struct {\nB []E `g:\"sensitive\"`\n}",
+			expected: "sensitive rule is not supported on nested structs",
+		},
+		{
+			name:     "invalid-regex",
+			source:   "type T struct {\nA string `g:\"regex=(abc\"`\n}",
+			expected: "invalid regex pattern",
+		},
+		{
+			name:     "custom-rule-parameters",
+			source:   "type T struct {\nA int `g:\"divisible_by=3\"`\n}",
+			genExpr:  "G",
+			expected: "parameters of divisible_by custom rule are not supported",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte("package p\n\n"+s.source+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, _, err := generate(dir, nil, s.genExpr)
+			if err == nil || !strings.Contains(err.Error(), s.expected) {
+				t.Errorf("expected = %s, err = %v", s.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package galidator
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Type of keys which galidator stores in a context
+	contextKey string
+
+	// Returned by validation methods instead of validation errors when the context is canceled or its deadline is exceeded
+	CanceledError struct {
+		// Error of the context, context.Canceled or context.DeadlineExceeded
+		Err error
+	}
+)
+
+const (
+	// Key of active validation groups in context
+	groupsContextKey contextKey = "galidator_groups"
+	// Key of partial validation mode in context
+	partialContextKey contextKey = "galidator_partial"
+	// Key of present keys of input in context
+	presentKeysContextKey contextKey = "galidator_present_keys"
+	// Key of async validation options in context
+	asyncContextKey contextKey = "galidator_async"
+	// Key of locale of error messages in context
+	localeContextKey contextKey = "galidator_locale"
+	// Key of translator of error messages in context
+	translatorContextKey contextKey = "galidator_translator"
+)
+
+func (err *CanceledError) Error() string {
+	return "validation canceled: " + err.Err.Error()
+}
+
+func (err *CanceledError) Unwrap() error {
+	return err.Err
+}
+
+// Returns true if passed output of a validation method is a *CanceledError
+func IsCanceled(output interface{}) bool {
+	_, ok := output.(*CanceledError)
+	return ok
+}
+
+// Returns a *CanceledError if passed context is done, returns nil otherwise
+func checkContext(ctx context.Context) *CanceledError {
+	if ctx == nil || ctx.Err() == nil {
+		return nil
+	}
+	return &CanceledError{Err: ctx.Err()}
+}
+
+// Returns a copy of passed context which activates passed validation groups
+//
+// Rules which are limited to some groups by `Groups` method of ruleSet (or `@` in tags) only
+// apply if at least one of their groups is active, other rules always apply
+func WithGroups(ctx context.Context, groups ...string) context.Context {
+	active := map[string]bool{}
+	for _, group := range groups {
+		active[group] = true
+	}
+	return context.WithValue(ctx, groupsContextKey, active)
+}
+
+// Returns active validation groups which are stored in passed context
+func groupsFromContext(ctx context.Context) map[string]bool {
+	if ctx == nil {
+		return nil
+	}
+	groups, _ := ctx.Value(groupsContextKey).(map[string]bool)
+	return groups
+}
+
+// Returns a copy of passed context which holds passed locale
+//
+// Validation methods which receive the returned context take error messages from catalog of passed locale
+// instead of locale of the generator, see `ParseAcceptLanguage` to find locale of a request
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey, locale)
+}
+
+// Returns locale which is stored in passed context, returns an empty string if there is no locale in it
+//
+// Custom validators can use it to localize their behavior
+func LocaleFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	locale, _ := ctx.Value(localeContextKey).(string)
+	return locale
+}
+
+// Returns a copy of passed context which holds passed translator
+//
+// Validation methods which receive the returned context translate error messages with passed translator
+// if no translator is passed to them directly
+func WithTranslator(ctx context.Context, translator Translator) context.Context {
+	return context.WithValue(ctx, translatorContextKey, translator)
+}
+
+// Returns translator which is stored in passed context, returns nil if there is no translator in it
+//
+// Custom validators can use it to translate their inputs or outputs
+func TranslatorFromContext(ctx context.Context) Translator {
+	if ctx == nil {
+		return nil
+	}
+	translator, _ := ctx.Value(translatorContextKey).(Translator)
+	return translator
+}
+
+// Returns a copy of passed context which holds keys that are present in input of partial validation
+//
+// Keys of nested objects and arrays are separated by dots, like: `address.zip` or `addresses.0.zip`
+//
+// If no key starts with name of a nested object, presence of its fields is detected from their values
+func WithPresentKeys(ctx context.Context, keys ...string) context.Context {
+	present := map[string]bool{}
+	for _, key := range keys {
+		present[key] = true
+	}
+	return context.WithValue(ctx, presentKeysContextKey, present)
+}
+
+// Returns all keys of passed JSON data in a format which `WithPresentKeys` accepts
+func JSONKeys(data []byte) ([]string, error) {
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, element := range v {
+				keys = append(keys, prefix+key)
+				walk(prefix+key+".", element)
+			}
+		case []interface{}:
+			for i, element := range v {
+				keys = append(keys, prefix+strconv.Itoa(i))
+				walk(prefix+strconv.Itoa(i)+".", element)
+			}
+		}
+	}
+	walk("", decoded)
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// Returns true if partial validation is requested in passed context
+func isPartial(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	partial, _ := ctx.Value(partialContextKey).(bool)
+	return partial
+}
+
+// Returns present keys which are stored in passed context
+func presentKeysFromContext(ctx context.Context) map[string]bool {
+	if ctx == nil {
+		return nil
+	}
+	keys, _ := ctx.Value(presentKeysContextKey).(map[string]bool)
+	return keys
+}
+
+// Returns a copy of passed context which holds present keys of nested object or array called name
+func nestedPresence(ctx context.Context, name string) context.Context {
+	keys := presentKeysFromContext(ctx)
+	if keys == nil {
+		return ctx
+	}
+
+	nested := map[string]bool{}
+	for key := range keys {
+		if strings.HasPrefix(key, name+".") {
+			nested[key[len(name)+1:]] = true
+		}
+	}
+	if len(nested) == 0 {
+		nested = nil
+	}
+	return context.WithValue(ctx, presentKeysContextKey, nested)
+}
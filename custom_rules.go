@@ -0,0 +1,256 @@
+package galidator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	gStrings "github.com/golodash/godash/strings"
+)
+
+type (
+	// A custom rule with its error messages, see `RegisterRules` method of generator
+	CustomRule struct {
+		// Key of the rule in error messages and options, like: `divisible_by`
+		Key string
+		// Name of the rule in struct tags, Key is used if it is empty
+		//
+		// Error messages in struct tags still use Key, like: `g:"divisible=3" divisible_by:"$field is invalid"`
+		Tag string
+		// Validates input, params are parameters of the rule, like `3` in `g:"divisible_by=3"`
+		Func func(ctx context.Context, input interface{}, params ...string) bool
+		// Names of parameters which error messages can use as placeholders in order, like `divisor` for `$divisor`
+		Params []string
+		// Default error message of the rule, it is used if no locale of Translations matches
+		Message string
+		// Error messages of the rule in other locales with locales as keys
+		Translations Messages
+	}
+
+	// Error messages of custom rules of a generator with normalized locales as keys, DefaultLocale holds Message of rules
+	//
+	// They are used after messages of validators and catalogs, so they never replace messages of other generators
+	ruleMessages map[string]Messages
+
+	// Determines what happens when a validator gets built with a rule which has no error message anywhere
+	MissingMessageMode int
+)
+
+const (
+	// Rules without error message are allowed silently, default mode
+	IgnoreMissingMessages MissingMessageMode = iota
+	// Passes a warning for every rule without error message to the function which is passed to MissingMessages
+	WarnMissingMessages
+	// Building fails with a *BuildError
+	FailOnMissingMessages
+)
+
+func (o *generatorS) RegisterRules(rules ...CustomRule) generator {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	customRules := map[string]CustomRule{}
+	for key, rule := range o.customRules {
+		customRules[key] = rule
+	}
+	// Copied, so validators which are already built keep messages they have
+	messages := ruleMessages{}
+	for locale, localeMessages := range o.ruleMessages {
+		messages[locale] = Messages{}
+		for key, message := range localeMessages {
+			messages[locale][key] = message
+		}
+	}
+	add := func(locale, key, message string) {
+		locale = normalizeLocale(locale)
+		if messages[locale] == nil {
+			messages[locale] = Messages{}
+		}
+		messages[locale][key] = message
+	}
+	for _, rule := range rules {
+		if rule.Key == "" {
+			panic("Key of a custom rule can not be empty")
+		}
+		if rule.Func == nil {
+			panic(fmt.Sprintf("Func of %s custom rule can not be nil", rule.Key))
+		}
+		if rule.Tag == "" {
+			rule.Tag = rule.Key
+		}
+		customRules[rule.Key] = rule
+		customRules[rule.Tag] = rule
+
+		if rule.Message != "" {
+			add(DefaultLocale, rule.Key, rule.Message)
+		}
+		for locale, message := range rule.Translations {
+			add(locale, rule.Key, message)
+		}
+	}
+	o.customRules = customRules
+	o.ruleMessages = messages
+	return o
+}
+
+func (o *generatorS) MissingMessages(mode MissingMessageMode, warn ...func(err error)) generator {
+	var warnFunc func(err error) = nil
+	if len(warn) != 0 {
+		warnFunc = warn[0]
+	}
+	if mode == WarnMissingMessages && warnFunc == nil {
+		panic("WarnMissingMessages mode needs a function to pass warnings to")
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.missingMessages = mode
+	o.warnMissingMessage = warnFunc
+	return o
+}
+
+// Returns message of passed ruleKey for passed locale, like `fa` for `fa-IR`, falls back to DefaultLocale
+func (o ruleMessages) get(locale string, ruleKey string) (string, bool) {
+	locale = normalizeLocale(locale)
+	locales := []string{locale}
+	if index := strings.Index(locale, "-"); index != -1 {
+		locales = append(locales, locale[:index])
+	}
+	for _, l := range append(locales, DefaultLocale) {
+		if message, ok := o[l][ruleKey]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+// Returns the custom rule which is registered with passed key or tag name
+func (o *generatorS) getCustomRule(name string) (CustomRule, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	rule, ok := o.customRules[name]
+	return rule, ok
+}
+
+// Looks for rules of passed validator which have no error message in passed messages, message functions, catalogs
+// and messages of custom rules of the generator
+//
+// Returns an error for each of them if mode of the generator is FailOnMissingMessages,
+// passes them to warning function of the generator if it is WarnMissingMessages
+func (o *generatorS) checkMessages(v Validator, messages Messages, messageFuncs MessageFuncs, locale string) []error {
+	o.mu.RLock()
+	mode, warn, customMessages := o.missingMessages, o.warnMissingMessage, o.ruleMessages
+	o.mu.RUnlock()
+	if mode == IgnoreMissingMessages {
+		return nil
+	}
+
+	hasMessage := func(ruleKey string) bool {
+		if _, ok := messages[ruleKey]; ok {
+			return true
+		}
+		if _, ok := messageFuncs[ruleKey]; ok {
+			return true
+		}
+		if _, ok := catalogMessage(locale, ruleKey); ok {
+			return true
+		}
+		_, ok := customMessages.get(locale, ruleKey)
+		return ok
+	}
+	keys := map[string]bool{}
+	for _, key := range collectMissingMessages(v, hasMessage) {
+		keys[key] = true
+	}
+	missing := []string{}
+	for key := range keys {
+		missing = append(missing, key)
+	}
+	sort.Strings(missing)
+
+	output := []error{}
+	for _, key := range missing {
+		err := fmt.Errorf("no error message exists on '%s' rule key", key)
+		if mode == FailOnMissingMessages {
+			output = append(output, err)
+		} else {
+			warn(err)
+		}
+	}
+	return output
+}
+
+// Returns keys of rules of passed validator and its nested validators which have no specific message and hasMessage returns false for them
+func collectMissingMessages(v Validator, hasMessage func(ruleKey string) bool) []string {
+	output := []string{}
+	r := v.getRule()
+	if r != nil {
+		output = append(output, collectRuleSetMissingMessages(r, hasMessage)...)
+	}
+	for _, variant := range v.getVariants() {
+		output = append(output, collectMissingMessages(variant, hasMessage)...)
+	}
+	for _, r := range v.getRules() {
+		if r != nil {
+			output = append(output, collectRuleSetMissingMessages(r, hasMessage)...)
+		}
+	}
+	return output
+}
+
+// Returns keys of rules of passed ruleSet and its deep and children validators which have no error message
+func collectRuleSetMissingMessages(r ruleSet, hasMessage func(ruleKey string) bool) []string {
+	output := r.missingMessages(hasMessage)
+	if v1 := r.getChildrenValidator(); v1 != nil {
+		output = append(output, collectMissingMessages(v1, hasMessage)...)
+	}
+	if v2 := r.getDeepValidator(); v2 != nil {
+		output = append(output, collectMissingMessages(v2, hasMessage)...)
+	}
+	return output
+}
+
+func (o *ruleSetS) Rule(name string, params ...string) ruleSet {
+	rule, ok := o.customRules[name]
+	if !ok {
+		panic(fmt.Sprintf("%s custom rule doesn't exist, call RegisterRules function of generator before using it", name))
+	}
+	if len(params) != len(rule.Params) {
+		panic(fmt.Sprintf("%s custom rule needs %d parameters, %d passed", name, len(rule.Params), len(params)))
+	}
+	if _, ok := o.validators[rule.Key]; ok {
+		panic(fmt.Sprintf("%s is duplicate and has to be unique", rule.Key))
+	}
+	function := rule.Func
+	o.validators[rule.Key] = func(ctx context.Context, input interface{}) bool {
+		return function(ctx, input, params...)
+	}
+	for i, param := range rule.Params {
+		o.addOption(rule.Key, param, params[i])
+	}
+	return o
+}
+
+func (o *ruleSetS) missingMessages(hasMessage func(ruleKey string) bool) []string {
+	output := []string{}
+	for key := range o.validators {
+		specificKey := gStrings.SnakeCase(key)
+		if _, ok := o.specificMessages[specificKey]; ok {
+			continue
+		}
+		if _, ok := o.specificMessageFuncs[specificKey]; ok {
+			continue
+		}
+		if !hasMessage(key) {
+			output = append(output, key)
+		}
+	}
+	for _, c := range o.conditions {
+		if c.then != nil {
+			output = append(output, c.then.missingMessages(hasMessage)...)
+		}
+		if c.otherwise != nil {
+			output = append(output, c.otherwise.missingMessages(hasMessage)...)
+		}
+	}
+	return output
+}
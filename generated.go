@@ -0,0 +1,55 @@
+package galidator
+
+import "context"
+
+type (
+	// A rule which failed in code generated by galidator-gen, see FormatMessage
+	GeneratedMessage struct {
+		// Key of the rule, like `min`
+		Key string
+		// Message of the rule in tags of the field, messages of the generator and catalogs are used if it is empty
+		Message string
+		// Name of the field which replaces `$field`
+		Field string
+		// Label of the field, replaces Field after it gets translated like labels of ruleSets, see `Label` method of ruleSet
+		Label string
+		// Value of the field which replaces `$value`
+		Value interface{}
+		// Options of the rule which replace their placeholders, like `min` of min rule
+		Options map[string]string
+	}
+)
+
+// Formats error message of a rule which failed in code generated by galidator-gen like a validator does,
+// locale and translator of ctx and catalogs are used, use FormatMessage method of a generator to use its messages too
+func FormatMessage(ctx context.Context, message GeneratedMessage) string {
+	return formatGeneratedMessage(ctx, message, nil, nil, nil, "")
+}
+
+func (o *generatorS) FormatMessage(ctx context.Context, message GeneratedMessage) string {
+	o.mu.RLock()
+	messages, messageFuncs, customMessages, locale := o.messages, o.messageFuncs, o.ruleMessages, o.locale
+	o.mu.RUnlock()
+	return formatGeneratedMessage(ctx, message, messages, messageFuncs, customMessages, locale)
+}
+
+// Formats passed message like validateRuleSet does with passed messages, message funcs, messages of custom rules and locale
+func formatGeneratedMessage(ctx context.Context, message GeneratedMessage, messages Messages, messageFuncs MessageFuncs, customMessages ruleMessages, locale string) string {
+	if contextLocale := LocaleFromContext(ctx); contextLocale != "" {
+		locale = contextLocale
+	}
+	var specificMessages Messages = nil
+	if message.Message != "" {
+		specificMessages = Messages{message.Key: message.Message}
+	}
+	t := TranslatorFromContext(ctx)
+	fieldName := message.Field
+	if message.Label != "" {
+		fieldName = translateLabel(message.Label, locale, t)
+	}
+	opt := option{}
+	for key, value := range message.Options {
+		opt[key] = value
+	}
+	return errorMessage(ctx, message.Key, fieldName, message.Value, opt, messages, messageFuncs, specificMessages, nil, customMessages, locale, t)
+}
@@ -1,17 +1,55 @@
 package galidator
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 )
 
 type (
 	// A struct to implement generator interface
 	generatorS struct {
+		// Guards settings of the generator, so one generator can be used from many goroutines
+		mu sync.RWMutex
 		// Custom validators
 		customValidators Validators
+		// Custom rules with their keys and tag names as keys
+		customRules map[string]CustomRule
+		// Error messages of custom rules
+		ruleMessages ruleMessages
+		// Determines what happens when a validator gets built with a rule which has no error message
+		missingMessages MissingMessageMode
+		// Receives warnings of rules without error message in WarnMissingMessages mode
+		warnMissingMessage func(err error)
 		// Custom error messages
 		messages Messages
+		// Custom message functions
+		messageFuncs MessageFuncs
+		// Validators registered for concrete types of values in interface typed fields
+		typeValidators *typeValidators
+		// Match timeout of regexes, 0 means no timeout
+		regexTimeout time.Duration
+		// Locale of catalog which error messages come from if there is no custom message for a rule
+		locale string
+	}
+
+	// Returned when a validator can not be generated, like when a regex pattern is invalid
+	BuildError struct {
+		// Every error which happened while building ruleSets of the validator
+		Errors []error
+	}
+
+	// Holds validators which are registered for concrete types
+	typeValidators struct {
+		// Guards validators and fallback, types can get registered while other validators are validating
+		mu sync.RWMutex
+		// Validators with type of their value as key
+		validators map[reflect.Type]Validator
+		// Used when no validator is registered for type of the value
+		fallback Validator
 	}
 
 	// An interface to generate a validator or ruleSet
@@ -24,12 +62,50 @@ type (
 		//
 		// Call this method before calling `generator.Validator` method to have effect
 		CustomMessages(messages Messages) generator
+		// Overrides current message functions(if there are some) with passed message functions
+		//
+		// A function wins over a message of the same rule in CustomMessages, messages which are passed
+		// to `generator.Validator` method win over these functions
+		//
+		// Call this method before calling `generator.Validator` method to have effect
+		CustomMessageFuncs(messageFuncs MessageFuncs) generator
+		// Registers passed custom rules with their error messages, rules get added to existing ones
+		//
+		// Registered rules can be used in struct tags by their tag names and in ruleSets by `Rule` method,
+		// messages and translations of them are only used by validators of this generator, after messages and catalogs
+		//
+		// Call this method before calling `generator.Validator` method to have effect
+		RegisterRules(rules ...CustomRule) generator
+		// Sets what happens when a validator gets built with a rule which has no error message anywhere, default is IgnoreMissingMessages
+		//
+		// In WarnMissingMessages mode, warn receives an error for every rule without error message and it is required
+		//
+		// Call this method before calling `generator.Validator` method to have effect
+		MissingMessages(mode MissingMessageMode, warn ...func(err error)) generator
+		// Sets the maximum time a regex can spend on matching an input, input does not match if it times out
+		//
+		// Protects against catastrophic backtracking of patterns, 0 (default) means no timeout
+		//
+		// Call this method before calling `generator.RuleSet` method to have effect
+		RegexTimeout(timeout time.Duration) generator
+		// Sets locale of catalog which error messages come from if there is no custom message for a rule, default is `en`
+		//
+		// Locales like `fa-IR` fall back to `fa`, see `RegisterCatalog` to add or change catalogs
+		//
+		// Call this method before calling `generator.Validator` method to have effect
+		Locale(locale string) generator
 		// Generates a validator interface which can be used to validate struct or map by some rules.
 		//
 		// `input` can be a ruleSet or a struct instance.
 		//
 		// Please use CapitalCase for rules' keys (Important for getting data out of struct types)
+		//
+		// Panics with a *BuildError if a ruleSet is invalid, like when a regex pattern can not be compiled
+		//
+		// Passed ruleSets get cloned, changing them afterwards has no effect on the validator
 		Validator(input interface{}, messages ...Messages) Validator
+		// Works like Validator method, but returns a *BuildError instead of panicking if a ruleSet is invalid
+		Build(input interface{}, messages ...Messages) (Validator, error)
 		// Generates a validator interface which can be used to validate struct or map or slice by passing an instance of them
 		validator(input interface{}) Validator
 		// Generates a ruleSet to validate passed information
@@ -39,27 +115,133 @@ type (
 		// An alias for RuleSet function
 		R(name ...string) ruleSet
 		// Generates a complex validator to validate maps and structs
+		//
+		// Passed ruleSets get cloned, changing them afterwards has no effect on the validator
 		ComplexValidator(rules Rules, messages ...Messages) Validator
+		// Registers a validator for the concrete type of passed sample
+		//
+		// When an interface typed field holds a value of this type, registered validator validates it
+		//
+		// If no validator is passed, one gets generated from struct tags of the sample, samples which are not
+		// structs, slices or arrays get a validator with an empty ruleSet, so values of their type are accepted as they are
+		RegisterType(sample interface{}, validator ...Validator) generator
+		// Sets the validator which is used when an interface typed field holds a value with no registered validator
+		DefaultTypeValidator(validator Validator) generator
+		// Returns the custom validator which is registered with passed key, returns nil if there is no such validator
+		//
+		// Custom rules which are registered by RegisterRules and have no parameters are returned too
+		GetCustomValidator(key string) func(ctx context.Context, input interface{}) bool
+		// Returns custom error messages of the generator
+		GetMessages() Messages
+		// Formats error message of a rule which failed in code generated by galidator-gen like a validator
+		// of the generator does, messages, message functions, messages of custom rules and locale of the generator are used
+		FormatMessage(ctx context.Context, message GeneratedMessage) string
 	}
 )
 
+// Returns the validator registered for dynamic type of passed input, or the fallback validator if no validator is registered
+func (o *typeValidators) get(input interface{}) Validator {
+	inputType := reflect.TypeOf(input)
+	for inputType != nil && inputType.Kind() == reflect.Ptr {
+		inputType = inputType.Elem()
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if validator, ok := o.validators[inputType]; ok {
+		return validator
+	}
+	return o.fallback
+}
+
 func (o *generatorS) CustomValidators(validators Validators) generator {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	o.customValidators = validators
 	return o
 }
 
 func (o *generatorS) CustomMessages(messages Messages) generator {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	o.messages = messages
 	return o
 }
 
-func (o *generatorS) Validator(rule interface{}, errorMessages ...Messages) Validator {
-	var messages Messages = o.messages
+func (o *generatorS) CustomMessageFuncs(messageFuncs MessageFuncs) generator {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messageFuncs = messageFuncs
+	return o
+}
+
+func (o *generatorS) RegexTimeout(timeout time.Duration) generator {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.regexTimeout = timeout
+	return o
+}
+
+func (o *generatorS) Locale(locale string) generator {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.locale = locale
+	return o
+}
+
+// Returns custom validator with passed key, returns nil if it does not exist
+func (o *generatorS) customValidator(key string) func(ctx context.Context, input interface{}) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.customValidators[key]
+}
+
+func (o *generatorS) GetCustomValidator(key string) func(ctx context.Context, input interface{}) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if function, ok := o.customValidators[key]; ok {
+		return function
+	}
+	if rule, ok := o.customRules[key]; ok && len(rule.Params) == 0 {
+		return func(ctx context.Context, input interface{}) bool {
+			return rule.Func(ctx, input)
+		}
+	}
+	return nil
+}
+
+func (o *generatorS) GetMessages() Messages {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.messages
+}
+
+// Returns a copy of messages and message functions of the generator with passed messages added to them,
+// messages of custom rules and locale of the generator
+//
+// Every validator gets its own copy, so messages passed for one validator do not leak into others
+func (o *generatorS) copyMessages(errorMessages ...Messages) (Messages, MessageFuncs, ruleMessages, string) {
+	o.mu.RLock()
+	messages := Messages{}
+	for key, value := range o.messages {
+		messages[key] = value
+	}
+	messageFuncs := MessageFuncs{}
+	for key, value := range o.messageFuncs {
+		messageFuncs[key] = value
+	}
+	customMessages, locale := o.ruleMessages, o.locale
+	o.mu.RUnlock()
 	if len(errorMessages) != 0 {
 		for key, value := range errorMessages[0] {
 			messages[key] = value
+			delete(messageFuncs, key)
 		}
 	}
+	return messages, messageFuncs, customMessages, locale
+}
+
+func (o *generatorS) Validator(rule interface{}, errorMessages ...Messages) Validator {
+	messages, messageFuncs, customMessages, locale := o.copyMessages(errorMessages...)
 	switch rule := rule.(type) {
 	case ruleSet:
 		break
@@ -72,28 +254,45 @@ func (o *generatorS) Validator(rule interface{}, errorMessages ...Messages) Vali
 	var output Validator = nil
 	switch v := rule.(type) {
 	case ruleSet:
-		output = &validatorS{rule: v, rules: nil, messages: nil}
+		output = &validatorS{rule: v.Clone(), rules: nil, messages: nil}
 	default:
-		if reflect.TypeOf(v).Kind() == reflect.Struct || reflect.TypeOf(v).Kind() == reflect.Slice {
+		if kind := reflect.TypeOf(v).Kind(); kind == reflect.Struct || kind == reflect.Slice || kind == reflect.Array {
 			output = o.validator(v)
 		} else {
 			panic("'rule' has to be a ruleSet or a struct instance")
 		}
 	}
-	deepPassMessages(output, &messages)
+	errs := append(collectErrors(output), o.checkMessages(output, messages, messageFuncs, locale)...)
+	if len(errs) != 0 {
+		panic(&BuildError{Errors: errs})
+	}
+	deepPassMessages(output, &messages, messageFuncs, customMessages, locale)
+	deepPassSensitive(output, false)
 
 	return output
 }
 
+func (o *generatorS) Build(input interface{}, messages ...Messages) (output Validator, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			buildError, ok := r.(*BuildError)
+			if !ok {
+				panic(r)
+			}
+			output, err = nil, buildError
+		}
+	}()
+
+	return o.Validator(input, messages...), nil
+}
+
 func (o *generatorS) validator(input interface{}) Validator {
-	inputValue := reflect.ValueOf(input)
 	inputType := reflect.TypeOf(input)
 	r := o.RuleSet()
 	if inputType.Kind() == reflect.Struct {
 		rules := Rules{}
-		for i := 0; i < inputType.NumField(); i++ {
-			elementT := inputType.Field(i)
-			element := inputValue.Field(i)
+		for _, elementT := range promotedFields(inputType) {
+			element := reflect.New(elementT.Type).Elem()
 			for element.Type().Kind() == reflect.Ptr {
 				elementType := element.Type().Elem()
 				element = reflect.New(elementType).Elem()
@@ -101,27 +300,29 @@ func (o *generatorS) validator(input interface{}) Validator {
 			}
 			tags := []string{elementT.Tag.Get("g"), elementT.Tag.Get("galidator")}
 			r = o.RuleSet(elementT.Tag.Get("json"))
+			r.setKind(elementT.Type.Kind())
+			if label := elementT.Tag.Get("label"); label != "" {
+				r.Label(label)
+			}
 
-			if elementT.Type.Kind() == reflect.Struct || elementT.Type.Kind() == reflect.Map {
+			if elementT.Type.Kind() == reflect.Interface {
+				r.Dynamic()
+			} else if elementT.Type.Kind() == reflect.Struct || elementT.Type.Kind() == reflect.Map {
 				validator := o.validator(element.Interface())
 				r.setDeepValidator(validator)
-			} else if elementT.Type.Kind() == reflect.Slice {
-				child := elementT.Type.Elem()
-				if child.Kind() != reflect.Slice && child.Kind() != reflect.Struct && child.Kind() != reflect.Map {
-					r.Children(o.R().Type(child))
-				} else {
-					validator := o.validator(reflect.Zero(elementT.Type.Elem()).Interface())
-					r.setChildrenValidator(validator)
-				}
+			} else if elementT.Type.Kind() == reflect.Slice || elementT.Type.Kind() == reflect.Array {
+				o.setChildren(r, elementT.Type.Elem())
 			}
 
 			// Add messages of rules
 			for _, fullTag := range tags {
 				filters := strings.Split(fullTag, ",")
 				for j := 0; j < len(filters); j++ {
-					tag := strings.SplitN(filters[j], "=", 2)
+					filter, groups := splitGroups(filters[j])
+					tag := strings.SplitN(filter, "=", 2)
 
 					normalFuncName := applyRules(r, tag, o, true)
+					addGroups(r, normalFuncName, groups)
 					value := elementT.Tag.Get("_" + normalFuncName)
 					if value == "" {
 						value = elementT.Tag.Get(normalFuncName)
@@ -147,14 +348,8 @@ func (o *generatorS) validator(input interface{}) Validator {
 		}
 
 		return &validatorS{rules: rules}
-	} else if inputType.Kind() == reflect.Slice {
-		child := inputType.Elem()
-		if child.Kind() != reflect.Slice && child.Kind() != reflect.Struct && child.Kind() != reflect.Map {
-			r.Children(o.R().Type(child))
-		} else {
-			validator := o.validator(reflect.Zero(child).Interface())
-			r.setChildrenValidator(validator)
-		}
+	} else if inputType.Kind() == reflect.Slice || inputType.Kind() == reflect.Array {
+		o.setChildren(r, inputType.Elem())
 
 		return &validatorS{rule: r}
 	} else if inputType.Kind() == reflect.Map {
@@ -166,13 +361,34 @@ func (o *generatorS) validator(input interface{}) Validator {
 	}
 }
 
+// Sets children validator of passed ruleSet based on type of elements of a slice or an array
+//
+// Pointer elements get validated by the type they point to
+func (o *generatorS) setChildren(r ruleSet, child reflect.Type) {
+	for child.Kind() == reflect.Ptr {
+		child = child.Elem()
+	}
+	switch child.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Struct, reflect.Map:
+		validator := o.validator(reflect.Zero(child).Interface())
+		r.setChildrenValidator(validator)
+	case reflect.Interface:
+		r.Children(o.R().Dynamic())
+	default:
+		r.Children(o.R().Type(child))
+	}
+}
+
 func (o *generatorS) RuleSet(name ...string) ruleSet {
 	var output = ""
 	if len(name) != 0 {
 		output = name[0]
 	}
-	ruleSet := &ruleSetS{name: output, validators: Validators{}, requires: requires{}, options: options{}, isOptional: true}
-	return ruleSet.setGeneratorCustomValidators(&o.customValidators)
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	ruleSet := &ruleSetS{name: output, validators: Validators{}, requires: requires{}, options: options{}, isOptional: true, typeValidators: o.typeValidators, regexTimeout: o.regexTimeout, customRules: o.customRules}
+	// Custom validators get looked up when they are used, so ruleSets see custom validators which are set later too
+	return ruleSet.setGeneratorCustomValidators(o.customValidator)
 }
 
 func (o *generatorS) R(name ...string) ruleSet {
@@ -180,23 +396,58 @@ func (o *generatorS) R(name ...string) ruleSet {
 }
 
 func (o *generatorS) ComplexValidator(rules Rules, errorMessages ...Messages) Validator {
-	var messages Messages = o.messages
-	if len(errorMessages) != 0 {
-		for key, value := range errorMessages[0] {
-			messages[key] = value
-		}
+	messages, messageFuncs, customMessages, locale := o.copyMessages(errorMessages...)
+	output := &validatorS{rule: nil, rules: rules.clone(), messages: nil}
+	errs := append(collectErrors(output), o.checkMessages(output, messages, messageFuncs, locale)...)
+	if len(errs) != 0 {
+		panic(&BuildError{Errors: errs})
 	}
-	output := &validatorS{rule: nil, rules: rules, messages: nil}
 
-	deepPassMessages(output, &messages)
+	deepPassMessages(output, &messages, messageFuncs, customMessages, locale)
+	deepPassSensitive(output, false)
 	return output
 }
 
+func (o *generatorS) RegisterType(sample interface{}, validator ...Validator) generator {
+	sampleType := reflect.TypeOf(sample)
+	for sampleType.Kind() == reflect.Ptr {
+		sampleType = sampleType.Elem()
+	}
+	var output Validator
+	if len(validator) != 0 {
+		output = validator[0]
+	} else if kind := sampleType.Kind(); kind == reflect.Struct || kind == reflect.Slice || kind == reflect.Array {
+		output = o.Validator(reflect.Zero(sampleType).Interface())
+	} else {
+		output = o.Validator(o.R())
+	}
+	o.typeValidators.mu.Lock()
+	defer o.typeValidators.mu.Unlock()
+	o.typeValidators.validators[sampleType] = output
+	return o
+}
+
+func (o *generatorS) DefaultTypeValidator(validator Validator) generator {
+	o.typeValidators.mu.Lock()
+	defer o.typeValidators.mu.Unlock()
+	o.typeValidators.fallback = validator
+	return o
+}
+
+func (err *BuildError) Error() string {
+	messages := []string{}
+	for _, e := range err.Errors {
+		messages = append(messages, e.Error())
+	}
+	return fmt.Sprintf("galidator: can not build validator: %s", strings.Join(messages, "; "))
+}
+
 // Returns a new Generator
 func NewGenerator() generator {
 	return &generatorS{
 		messages:         Messages{},
 		customValidators: Validators{},
+		typeValidators:   &typeValidators{validators: map[reflect.Type]Validator{}},
 	}
 }
 
@@ -0,0 +1,25 @@
+// Package shared holds definitions which galidator and galidator-gen have to agree on
+package shared
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// Pattern which passwords have to match: at least 8 characters long with one lowercase,
+// one uppercase, one special and one number character
+const PasswordPattern = "^(?=.*[a-z])(?=.*[A-Z])(?=.*\\d)(?=.*[ !\"#$%&'()*+,-.\\/:;<=>?@[\\]^_`{|}~])[A-Za-z\\d !\"#$%&'()*+,-.\\/:;<=>?@[\\]^_`{|}~]{8,}$"
+
+// Matches validation groups part of a filter in tags, like: `create|update`
+var GroupsPattern = regexp.MustCompile(`^\w+(\|\w+)*$`)
+
+// Determines the precision of a float number for print
+func DeterminePrecision(number float64) string {
+	for i := 0; ; i++ {
+		ten := math.Pow10(i)
+		if math.Floor(ten*number) == ten*number {
+			return fmt.Sprint(i)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package galidator
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Format of paths which address fields in flattened outputs, like `/addresses/0/zip` or `addresses.0.zip`
+	PathFormat int
+
+	// Error messages of one field in output of FlattenList
+	FieldErrors struct {
+		// Path of the field, an empty string addresses the input itself
+		Path string `json:"path"`
+		// Error messages of the field
+		Messages []string `json:"messages"`
+	}
+)
+
+const (
+	// RFC 6901 JSON Pointer, like `/addresses/0/zip`, `~` and `/` in names get escaped as `~0` and `~1`
+	JSONPointer PathFormat = iota
+	// Names separated by dots, like `addresses.0.zip`
+	DottedPath
+)
+
+// Returns passed segments of a path in passed format, root is an empty string in both formats
+func formatPath(segments []string, format PathFormat) string {
+	if format == DottedPath {
+		return strings.Join(segments, ".")
+	}
+	output := strings.Builder{}
+	for _, segment := range segments {
+		output.WriteByte('/')
+		output.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(segment))
+	}
+	return output.String()
+}
+
+// Returns passed output of a validation method (or DecryptErrors) as a flat map of error messages
+// with paths of fields in passed format as keys, like `/addresses/0/zip` or `addresses.0.zip`
+//
+// Returns nil if output has no error messages, like when it is nil or a *CanceledError
+func Flatten(errors interface{}, format PathFormat) map[string][]string {
+	var output map[string][]string
+	walkErrors(errors, func(segments []string, messages []string, rules []string) {
+		if output == nil {
+			output = map[string][]string{}
+		}
+		output[formatPath(segments, format)] = messages
+	})
+	return output
+}
+
+// Returns passed output of a validation method (or DecryptErrors) as a list of fields with their error messages,
+// fields are sorted by their path segments
+//
+// Returns nil if output has no error messages, like when it is nil or a *CanceledError
+func FlattenList(errors interface{}, format PathFormat) []FieldErrors {
+	var output []FieldErrors
+	walkErrors(errors, func(segments []string, messages []string, rules []string) {
+		output = append(output, FieldErrors{Path: formatPath(segments, format), Messages: messages})
+	})
+	return output
+}
+
+// Calls visit for every list of error messages in passed output of a validation method with segments of its path,
+// keys of maps get visited in sorted order and indexes of slices in numeric order
+//
+// rules holds keys of rules of messages if output has failures, otherwise it is nil
+func walkErrors(output interface{}, visit func(segments []string, messages []string, rules []string)) {
+	var walk func(segments []string, output interface{})
+	walk = func(segments []string, output interface{}) {
+		switch output := output.(type) {
+		case string:
+			// Messages of DecryptErrors
+			visit(segments, []string{output}, nil)
+		case []string:
+			if len(output) != 0 {
+				visit(segments, output, nil)
+			}
+		case []failure:
+			if len(output) != 0 {
+				messages := make([]string, len(output))
+				rules := make([]string, len(output))
+				for i, f := range output {
+					messages[i], rules[i] = f.message, f.rule
+				}
+				visit(segments, messages, rules)
+			}
+		case map[string]interface{}:
+			keys := make([]string, 0, len(output))
+			for key := range output {
+				keys = append(keys, key)
+			}
+			sort.Slice(keys, func(i, j int) bool {
+				// Indexes of slices get sorted by their numeric value
+				a, errA := strconv.Atoi(keys[i])
+				b, errB := strconv.Atoi(keys[j])
+				if errA == nil && errB == nil {
+					return a < b
+				}
+				return keys[i] < keys[j]
+			})
+			for _, key := range keys {
+				walk(append(segments[:len(segments):len(segments)], key), output[key])
+			}
+		}
+	}
+	walk([]string{}, output)
+}
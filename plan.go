@@ -0,0 +1,222 @@
+package galidator
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type (
+	// Fields of a struct type which a validator checks, resolved once on first validation of the type or by Compile method
+	structPlan struct {
+		fields []fieldPlan
+	}
+
+	// A resolved field of a struct type
+	fieldPlan struct {
+		// Rules of the field
+		ruleSet ruleSet
+		// Key of the field in rules of validator
+		key string
+		// Name of the field in error messages
+		name string
+		// Index sequence of the field, used like reflect.Value.FieldByIndex
+		index []int
+		// Type of the field
+		field reflect.StructField
+		// Kind of values of the field, reflect.Invalid if it depends on the value, like for interfaces and pointers
+		kind reflect.Kind
+		// Index sequences of fields which requires of the field check, with keys of the requires as keys
+		requires map[string][][]int
+	}
+)
+
+// Resolves fields of passed struct type and fields of its nested types and caches them
+func (o *validatorS) compile(inputType reflect.Type) {
+	for inputType.Kind() == reflect.Ptr {
+		inputType = inputType.Elem()
+	}
+
+	if o.variants != nil {
+		for _, variant := range o.variants {
+			variant.(*validatorS).compile(inputType)
+		}
+		return
+	} else if o.rule != nil {
+		compileNested(o.rule, inputType)
+		return
+	} else if o.rules == nil || inputType.Kind() != reflect.Struct {
+		return
+	}
+	if _, ok := o.plans.Load(inputType); ok {
+		return
+	}
+
+	fields := []fieldPlan{}
+	for _, key := range o.ruleKeys() {
+		ruleSet := o.rules[key]
+		name := key
+		if ruleSet.getName() != "" {
+			name = ruleSet.getName()
+		}
+
+		field, found := inputType.FieldByName(key)
+		if !found {
+			field, found = inputType.FieldByName(name)
+		}
+		if !found {
+			panic(fmt.Sprintf("value on %s is not valid", name))
+		}
+		// If not exported
+		if field.PkgPath != "" && !ruleSet.allowsUnexported() {
+			continue
+		}
+
+		fields = append(fields, fieldPlan{
+			ruleSet:  ruleSet,
+			key:      key,
+			name:     name,
+			index:    field.Index,
+			field:    field,
+			kind:     fieldKind(inputType, field),
+			requires: compileRequires(inputType, ruleSet),
+		})
+		compileNested(ruleSet, field.Type)
+	}
+	o.plans.Store(inputType, &structPlan{fields: fields})
+}
+
+// Returns kind of values of passed field of passed struct type, returns reflect.Invalid if it depends on the value
+//
+// Values of interfaces and pointers can have different kinds and promoted fields of embedded pointers can be nil
+func fieldKind(structType reflect.Type, field reflect.StructField) reflect.Kind {
+	for i := 1; i < len(field.Index); i++ {
+		if structType.FieldByIndex(field.Index[:i]).Type.Kind() == reflect.Ptr {
+			return reflect.Invalid
+		}
+	}
+	if kind := field.Type.Kind(); kind != reflect.Interface && kind != reflect.Ptr {
+		return kind
+	}
+	return reflect.Invalid
+}
+
+// Resolves index sequences of fields which requires of passed ruleSet check in passed struct type,
+// requires with fields which are not found are left out to fail like they do without a plan
+func compileRequires(structType reflect.Type, ruleSet ruleSet) map[string][][]int {
+	var output map[string][][]int
+	for key, require := range ruleSet.getRequires() {
+		indexes := make([][]int, 0, len(require.fields))
+		for _, name := range require.fields {
+			field, found := structType.FieldByName(name)
+			if !found {
+				break
+			}
+			indexes = append(indexes, field.Index)
+		}
+		if len(indexes) != len(require.fields) {
+			continue
+		}
+		if output == nil {
+			output = map[string][][]int{}
+		}
+		output[key] = indexes
+	}
+	return output
+}
+
+// Compiles deep and children validators of passed ruleSet for passed type
+func compileNested(ruleSet ruleSet, inputType reflect.Type) {
+	for inputType.Kind() == reflect.Ptr {
+		inputType = inputType.Elem()
+	}
+
+	if ruleSet.hasDeepValidator() {
+		ruleSet.getDeepValidator().(*validatorS).compile(inputType)
+	}
+	if ruleSet.hasChildrenValidator() && (inputType.Kind() == reflect.Slice || inputType.Kind() == reflect.Array) {
+		ruleSet.getChildrenValidator().(*validatorS).compile(inputType.Elem())
+	}
+}
+
+// Returns cached plan of passed struct type, compiles the type if it is not compiled yet
+func (o *validatorS) getPlan(inputType reflect.Type) *structPlan {
+	if plan, ok := o.plans.Load(inputType); ok {
+		return plan.(*structPlan)
+	}
+	o.compile(inputType)
+	if plan, ok := o.plans.Load(inputType); ok {
+		return plan.(*structPlan)
+	}
+	return nil
+}
+
+// Returns kind of passed value of a field, kind in plan of the field is used if it does not depend on the value
+//
+// field is nil for fields without a plan, like values of maps, pointers get dereferenced
+func kindOf(field *fieldPlan, value interface{}) reflect.Kind {
+	if field != nil && field.kind != reflect.Invalid {
+		return field.kind
+	}
+	return dereference(reflect.ValueOf(value)).Kind()
+}
+
+// Returns the value which passed value points to after dereferencing all its pointers, a nil pointer becomes invalid
+func dereference(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	return value
+}
+
+// Returns true if values of passed kind get validated by deep validators
+func isDeepKind(kind reflect.Kind) bool {
+	return kind == reflect.Map || kind == reflect.Struct || isSliceKind(kind)
+}
+
+// Returns true if values of passed kind get validated by children validators
+func isSliceKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// Returns values of fields which passed require of a field checks in passed struct or map called all,
+// index sequences in plan of the field are used if it has them
+//
+// Fields promoted through a nil embedded pointer are nil, like in validation of the fields themselves
+func requireValues(all interface{}, key string, require requireRule, field *fieldPlan) []interface{} {
+	if field == nil || field.requires[key] == nil {
+		return getValues(all, require.fields...)
+	}
+	allValue := reflect.ValueOf(all)
+	values := make([]interface{}, len(require.fields))
+	for i, index := range field.requires[key] {
+		if value := fieldByIndex(allValue, index); value.IsValid() {
+			values[i] = value.Interface()
+		}
+	}
+	return values
+}
+
+// Returns value of the field with passed index sequence in passed struct value
+//
+// If field is promoted through a nil embedded pointer, returned value is not valid
+func fieldByIndex(structValue reflect.Value, index []int) reflect.Value {
+	value := structValue
+	for i, x := range index {
+		if i != 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}
+			}
+			value = value.Elem()
+		}
+		value = value.Field(x)
+	}
+
+	return value
+}
+
+// Returns an addressable copy of passed struct value, fields of the copy can be read even if they are unexported
+func addressableCopy(structValue reflect.Value) reflect.Value {
+	output := reflect.New(structValue.Type()).Elem()
+	output.Set(structValue)
+	return output
+}
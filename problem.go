@@ -0,0 +1,158 @@
+package galidator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type (
+	// Configures Problem method and NewProblem function
+	ProblemOptions struct {
+		// URI which identifies the problem type, default is `about:blank`
+		Type string
+		// Short summary of the problem type, default is the status text of Status, like `Bad Request`
+		Title string
+		// HTTP status code, default is 400
+		Status int
+		// Explanation specific to this occurrence of the problem, omitted if empty
+		Detail string
+		// URI which identifies this occurrence of the problem, omitted if empty
+		Instance string
+		// Format of names of invalid params, default is JSONPointer
+		PathFormat PathFormat
+		// Translates error messages
+		Translator Translator
+		// Leaves out `errors` extension member, which holds output of Validate method, if true
+		OmitErrors bool
+	}
+
+	// A Problem Details document (RFC 9457, formerly RFC 7807) which describes validation errors
+	Problem struct {
+		Type          string         `json:"type"`
+		Title         string         `json:"title"`
+		Status        int            `json:"status"`
+		Detail        string         `json:"detail,omitempty"`
+		Instance      string         `json:"instance,omitempty"`
+		InvalidParams []InvalidParam `json:"invalid_params"`
+		// Output of Validate method, empty if OmitErrors option is true
+		Errors interface{} `json:"errors,omitempty"`
+	}
+
+	// One failed rule of a field in a Problem
+	InvalidParam struct {
+		// Path of the field in format of PathFormat option, an empty string addresses the input itself
+		Name string `json:"name"`
+		// Error message of the failed rule
+		Reason string `json:"reason"`
+		// Key of the failed rule, like `min`, empty if it is unknown
+		Rule string `json:"rule,omitempty"`
+	}
+
+	// Methods of *gin.Context which AbortWithProblem needs
+	ginContext interface {
+		Data(code int, contentType string, data []byte)
+		Abort()
+	}
+)
+
+// Content type of Problem Details documents
+const ProblemContentType = "application/problem+json"
+
+// Returns a copy of passed output of a validation method with error messages in place of failures
+func failureMessages(output interface{}) interface{} {
+	switch output := output.(type) {
+	case []failure:
+		return failuresOutput(output, false)
+	case map[string]interface{}:
+		messages := make(map[string]interface{}, len(output))
+		for key, value := range output {
+			messages[key] = failureMessages(value)
+		}
+		return messages
+	}
+	return output
+}
+
+// Returns a Problem which describes passed output of a validation method, returns nil if output is nil
+//
+// Rule of invalid params is empty because output does not contain keys of rules, use Problem method of Validator to have them
+func NewProblem(errors interface{}, options ...ProblemOptions) *Problem {
+	if errors == nil {
+		return nil
+	}
+	opts := ProblemOptions{}
+	if len(options) != 0 {
+		opts = options[0]
+	}
+	if opts.Type == "" {
+		opts.Type = "about:blank"
+	}
+	if opts.Status == 0 {
+		opts.Status = http.StatusBadRequest
+	}
+	if opts.Title == "" {
+		opts.Title = http.StatusText(opts.Status)
+	}
+
+	problem := &Problem{
+		Type:          opts.Type,
+		Title:         opts.Title,
+		Status:        opts.Status,
+		Detail:        opts.Detail,
+		Instance:      opts.Instance,
+		InvalidParams: []InvalidParam{},
+	}
+	walkErrors(errors, func(segments []string, messages []string, rules []string) {
+		name := formatPath(segments, opts.PathFormat)
+		for i, message := range messages {
+			param := InvalidParam{Name: name, Reason: message}
+			if rules != nil {
+				param.Rule = rules[i]
+			}
+			problem.InvalidParams = append(problem.InvalidParams, param)
+		}
+	})
+	if !opts.OmitErrors {
+		problem.Errors = failureMessages(errors)
+	}
+	return problem
+}
+
+func (o *validatorS) Problem(ctx context.Context, input interface{}, options ...ProblemOptions) (*Problem, error) {
+	var t Translator = nil
+	if len(options) != 0 && options[0].Translator != nil {
+		t = options[0].Translator
+	} else {
+		t = TranslatorFromContext(ctx)
+	}
+	// Failures keep keys of rules for invalid params
+	errors := o.validate(ctx, input, t, true)
+	if err, ok := errors.(*CanceledError); ok {
+		return nil, err
+	}
+	return NewProblem(errors, options...), nil
+}
+
+// Writes passed problem as response with its status and `application/problem+json` content type
+func WriteProblem(w http.ResponseWriter, problem *Problem) error {
+	data, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_, err = w.Write(data)
+	return err
+}
+
+// Writes passed problem as response of passed *gin.Context like WriteProblem and aborts the request
+func AbortWithProblem(c ginContext, problem *Problem) error {
+	data, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	c.Data(problem.Status, ProblemContentType, data)
+	c.Abort()
+	return nil
+}
@@ -0,0 +1,37 @@
+package galidator
+
+import "sync/atomic"
+
+// Replaces values of sensitive fields in error messages, see `Sensitive` method of ruleSet
+const RedactedValue = "[REDACTED]"
+
+// Maximum number of characters of values in error messages, 0 means no limit
+var maxValueLength int64
+
+// Limits number of characters of values which replace `$value` in error messages, longer values get cut and end with `...`
+//
+// It applies to all validators and generated methods, 0 (default) means no limit, returns the previous limit
+func TruncateValues(length int) int {
+	return int(atomic.SwapInt64(&maxValueLength, int64(length)))
+}
+
+// Returns passed value cut to the limit of TruncateValues
+func truncateValue(value string) string {
+	limit := int(atomic.LoadInt64(&maxValueLength))
+	if limit <= 0 || len(value) <= limit {
+		return value
+	}
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit]) + "..."
+}
+
+// Returns the value which error messages of passed ruleSet show for passed value, RedactedValue if ruleSet is sensitive
+func messageValue(ruleSet ruleSet, value interface{}) interface{} {
+	if ruleSet.isSensitive() {
+		return RedactedValue
+	}
+	return value
+}
@@ -1,71 +1,86 @@
 package galidator
 
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/golodash/godash/generals"
+)
+
+// Returns a predicate which returns true if value of passed field in the parent is equal to one of passed values
+//
+// Fields can be found by their name or the name defined in their json tag,
+// string values are compared with string form of the field too
+func FieldEquals(field string, values ...interface{}) Predicate {
+	return func(ctx context.Context, parent interface{}) bool {
+		fieldValue := valueOfKey(reflect.Indirect(reflect.ValueOf(parent)), field)
+		for _, value := range values {
+			if generals.Same(fieldValue, value) {
+				return true
+			}
+			if s, ok := value.(string); ok && fieldValue != nil && fmt.Sprint(fieldValue) == s {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // Returns false if one of the fields values is not empty, nil or zero and input is empty, nil or zero
 //
 // False means it is required and all validators have to check
-func whenExistOneRequireRule(fields ...string) func(interface{}) func(interface{}) bool {
-	return func(all interface{}) func(interface{}) bool {
-		fieldsValues := getValues(all, fields...)
-		return func(input interface{}) bool {
-			inputIsNil := isEmptyNilZero(input)
-			for _, element := range fieldsValues {
-				if !isEmptyNilZero(element) && inputIsNil {
-					return false
-				}
+func whenExistOneRequireRule(fields ...string) requireRule {
+	return requireRule{fields: fields, check: func(fieldsValues []interface{}, input interface{}) bool {
+		inputIsNil := isEmptyNilZero(input)
+		for _, element := range fieldsValues {
+			if !isEmptyNilZero(element) && inputIsNil {
+				return false
 			}
-			return true
 		}
-	}
+		return true
+	}}
 }
 
 // Returns false if all of the fields values are not empty, nil or zero and input is empty, nil or zero
 //
 // False means it is required and all validators have to check
-func whenExistAllRequireRule(fields ...string) func(interface{}) func(interface{}) bool {
-	return func(all interface{}) func(interface{}) bool {
-		fieldsValues := getValues(all, fields...)
-		return func(input interface{}) bool {
-			for _, element := range fieldsValues {
-				if isEmptyNilZero(element) {
-					return true
-				}
+func whenExistAllRequireRule(fields ...string) requireRule {
+	return requireRule{fields: fields, check: func(fieldsValues []interface{}, input interface{}) bool {
+		for _, element := range fieldsValues {
+			if isEmptyNilZero(element) {
+				return true
 			}
-			return !isEmptyNilZero(input)
 		}
-	}
+		return !isEmptyNilZero(input)
+	}}
 }
 
 // Returns false if one of the fields values is empty, nil or zero and input is empty, nil or zero
 //
 // False means it is required and all validators have to check
-func whenNotExistOneRequireRule(fields ...string) func(interface{}) func(interface{}) bool {
-	return func(all interface{}) func(interface{}) bool {
-		fieldsValues := getValues(all, fields...)
-		return func(input interface{}) bool {
-			inputIsNil := isEmptyNilZero(input)
-			for _, element := range fieldsValues {
-				if isEmptyNilZero(element) && inputIsNil {
-					return false
-				}
+func whenNotExistOneRequireRule(fields ...string) requireRule {
+	return requireRule{fields: fields, check: func(fieldsValues []interface{}, input interface{}) bool {
+		inputIsNil := isEmptyNilZero(input)
+		for _, element := range fieldsValues {
+			if isEmptyNilZero(element) && inputIsNil {
+				return false
 			}
-			return true
 		}
-	}
+		return true
+	}}
 }
 
 // Returns false if all of the fields values are empty, nil or zero and input is empty, nil or zero
 //
 // False means it is required and all validators have to check
-func whenNotExistAllRequireRule(fields ...string) func(interface{}) func(interface{}) bool {
-	return func(all interface{}) func(interface{}) bool {
-		fieldsValues := getValues(all, fields...)
-		return func(input interface{}) bool {
-			for _, element := range fieldsValues {
-				if !isEmptyNilZero(element) {
-					return true
-				}
+func whenNotExistAllRequireRule(fields ...string) requireRule {
+	return requireRule{fields: fields, check: func(fieldsValues []interface{}, input interface{}) bool {
+		for _, element := range fieldsValues {
+			if !isEmptyNilZero(element) {
+				return true
 			}
-			return !isEmptyNilZero(input)
 		}
-	}
+		return !isEmptyNilZero(input)
+	}}
 }
@@ -4,7 +4,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golodash/galidator/v2/internal/shared"
 )
 
 type (
@@ -18,12 +23,38 @@ type (
 	Validators map[string]func(ctx context.Context, input interface{}) bool
 
 	// A map full of field require determining
-	requires map[string]func(interface{}) func(interface{}) bool
+	requires map[string]requireRule
+
+	// Determines if a field is required based on values of other fields of its parent
+	requireRule struct {
+		// Names of the fields in the parent
+		fields []string
+		// Returns false if input is required based on values of the fields
+		check func(fieldsValues []interface{}, input interface{}) bool
+	}
+
+	// Decides which ruleSet of a condition applies on a field based on its parent value (struct or map)
+	Predicate func(ctx context.Context, parent interface{}) bool
+
+	// A condition which applies `then` ruleSet on a field if predicate returns true and `otherwise` ruleSet if not
+	condition struct {
+		predicate Predicate
+		then      ruleSet
+		otherwise ruleSet
+	}
+
+	// A ruleSet which is chosen by a condition with name of the branch (then or otherwise)
+	branch struct {
+		name string
+		rule ruleSet
+	}
 
 	// A struct to implement ruleSet interface
 	ruleSetS struct {
 		// The name that will be shown in output of the errors
 		name string
+		// The name that replaces `$field` in error messages, name is used if it is empty
+		label string
 		// Used to validate user's data
 		validators Validators
 		// Used to determine what needs to be required
@@ -32,16 +63,46 @@ type (
 		options options
 		// Sets messages for specific rules in current ruleSet
 		specificMessages Messages
+		// Sets message functions for specific rules in current ruleSet
+		specificMessageFuncs MessageFuncs
 		// If isOptional is true, if empty is sent, all errors will be ignored
 		isOptional bool
+		// If true, rules get checked even if value is empty, nil or zero
+		alwaysCheck bool
+		// Validation groups of rules with rule keys as keys, rules without groups always apply
+		groups map[string][]string
+		// Maximum durations of rules with rule keys as keys
+		timeouts map[string]time.Duration
+		// Keys of I/O-bound rules
+		async map[string]bool
+		// Sorted keys of validators, validators only get added so it is valid while its length is the same
+		sortedKeys atomic.Value
+		// If true, field gets validated even if it is an unexported field of a struct
+		allowUnexported bool
+		// If true, value of the field does not appear in error messages
+		sensitive bool
+		// Kind of the Go type of the field if ruleSet is generated from a struct, reflect.Invalid otherwise
+		kind reflect.Kind
 		// Holds data for more complex structures, like:
 		//
 		// map or struct
 		deepValidator Validator
 		// Defines type of elements of a slice
 		childrenValidator Validator
-		// Custom validators which is defined in generator
-		customValidators *Validators
+		// Returns custom validator which is defined in generator with passed key, nil if it does not exist
+		customValidator func(key string) func(ctx context.Context, input interface{}) bool
+		// Custom rules which are registered in generator with their keys and tag names as keys
+		customRules map[string]CustomRule
+		// If true, input gets validated by the validator registered in generator for its dynamic type
+		dynamic bool
+		// Conditions which choose extra ruleSets to apply on the field
+		conditions []*condition
+		// Validators which are registered for concrete types in generator
+		typeValidators *typeValidators
+		// Match timeout of regexes which get compiled in current ruleSet, 0 means no timeout
+		regexTimeout time.Duration
+		// Errors which happened while building current ruleSet, like invalid regex patterns
+		errors []error
 	}
 
 	// An interface with some functions to satisfy validation purpose
@@ -94,6 +155,8 @@ type (
 		// Checks if input is a valid email address
 		Email() ruleSet
 		// Validates inputs with passed pattern
+		//
+		// If pattern is invalid, generating a validator from current ruleSet fails with a BuildError
 		Regex(pattern string) ruleSet
 		// Checks if input is a valid phone number
 		Phone() ruleSet
@@ -101,9 +164,32 @@ type (
 		Custom(validators Validators) ruleSet
 		// Adds one custom validator which is registered before in generator
 		RegisteredCustom(validatorKeys ...string) ruleSet
+		// Adds a custom rule which is registered before by `RegisterRules` method of generator, name is its key or tag name
+		//
+		// Number of params has to be the same as number of parameters of the rule
+		Rule(name string, params ...string) ruleSet
+		// Limits rule with passed ruleKey to passed validation groups
+		//
+		// The rule applies only if at least one of its groups is activated by `WithGroups` or `ValidateGroups`
+		//
+		// Note: Rules without any groups always apply
+		Groups(ruleKey string, groups ...string) ruleSet
+		// Limits how long rule with passed ruleKey can take, rule fails with `timeout` message key if it does not return in time
+		//
+		// Context which rule receives gets canceled when timeout passes, useful for custom validators which
+		// do I/O, like checking a database
+		//
+		// A panic in the rule gets raised again in the goroutine which validates
+		//
+		// Note: A rule which ignores its context keeps running in background after timeout
+		Timeout(ruleKey string, timeout time.Duration) ruleSet
+		// Marks rules with passed ruleKeys as I/O-bound, like custom validators which check a database
+		//
+		// In async validation (see `WithAsync`), fields and slice elements with I/O-bound rules get validated concurrently
+		Async(ruleKeys ...string) ruleSet
 		// Checks if input is a map
 		Map() ruleSet
-		// Checks if input is a slice
+		// Checks if input is a slice or an array
 		Slice() ruleSet
 		// Checks if input is a struct
 		Struct() ruleSet
@@ -113,6 +199,14 @@ type (
 		Complex(rules Rules) ruleSet
 		// If children of a slice is not struct or map, use this function and otherwise use Complex function after Slice function
 		Children(rule ruleSet) ruleSet
+		// Validates a map or struct by one of passed variants, the one that its key is equal to value of discriminator field of input
+		//
+		// If value of discriminator field is not one of keys of variants, an error gets returned on discriminator field
+		OneOf(discriminator string, variants Variants) ruleSet
+		// Validates input by the validator which is registered in generator for dynamic type of input
+		//
+		// Used for interface typed fields, see `RegisterType` and `DefaultTypeValidator` methods of generator
+		Dynamic() ruleSet
 		// Checks if input is a Specific type
 		Type(input interface{}) ruleSet
 		// Checks if input is at least 8 characters long, has one lowercase, one uppercase and one number character
@@ -131,8 +225,31 @@ type (
 		WhenNotExistOne(choices ...string) ruleSet
 		// Makes field required if all passed fields are empty, nil or zero(0, "", '')
 		WhenNotExistAll(choices ...string) ruleSet
+		// Starts a condition, rules of ruleSet passed to the next `Then` call apply if predicate returns true
+		// and rules of ruleSet passed to the next `Otherwise` call apply if predicate returns false
+		//
+		// Predicate receives the parent struct or map of the field, or input itself if there is no parent
+		When(predicate Predicate) ruleSet
+		// Defines rules which apply on the field if predicate of the last `When` call returns true
+		//
+		// Applied branch is accessible in error messages as $branch, passed ruleSet gets cloned
+		Then(rule ruleSet) ruleSet
+		// Defines rules which apply on the field if predicate of the last `When` call returns false
+		//
+		// Applied branch is accessible in error messages as $branch
+		Otherwise(rule ruleSet) ruleSet
 		// Checks if input is a string
 		String() ruleSet
+		// Validates the field even if it is an unexported field of a struct
+		//
+		// Note: Value of the field gets read through reflection
+		AllowUnexported() ruleSet
+		// Hides value of the field in error messages and message functions, RedactedValue replaces it
+		//
+		// Note: Elements, fields and branches of a sensitive field are sensitive too
+		Sensitive() ruleSet
+		// Returns a deep copy of current ruleSet, changing the copy has no effect on current ruleSet and vice versa
+		Clone() ruleSet
 		// Returns Validator of current Element (For map and struct elements)
 		GetValidator() Validator
 		// Returns Validator of children Elements (For slices)
@@ -144,6 +261,16 @@ type (
 		SpecificMessages(specificMessages Messages) ruleSet
 		// Return specificMessages
 		getSpecificMessages() Messages
+		// Sets message functions for specific rules in current ruleSet, a function wins over a message of the same rule
+		SpecificMessageFuncs(specificMessageFuncs MessageFuncs) ruleSet
+		// Returns specificMessageFuncs
+		getSpecificMessageFuncs() MessageFuncs
+		// Sets the name which replaces `$field` in error messages, keys of errors in output do not change
+		//
+		// Label gets translated by the translator and by catalog of the locale with `label.` + label as key, like `label.First name`
+		Label(label string) ruleSet
+		// Returns label
+		getLabel() string
 
 		// Returns option of the passed ruleKey
 		getOption(ruleKey string) option
@@ -153,10 +280,26 @@ type (
 		optional()
 		// Makes the field required
 		required()
+		// Returns true if the field has to get validated even if it is unexported
+		allowsUnexported() bool
+		// Returns true if value of the field does not appear in error messages
+		isSensitive() bool
+		// Returns true if current ruleSet, its conditions or its nested validators have I/O-bound rules
+		//
+		// Dynamic ruleSets may have them too, because validator of the value is not known before validation
+		hasAsyncRules() bool
 		// Returns true if the ruleSet has to pass all validators
 		//
 		// Returns false if the ruleSet can be empty, nil or zero(0, "", '') and is allowed to not pass any validations
 		isRequired() bool
+		// Like isRequired, but returns false if rules which make the field required are not in active validation groups
+		isRequiredIn(ctx context.Context) bool
+		// Returns true if rule with passed ruleKey belongs to at least one of active validation groups or has no groups
+		isActive(ctx context.Context, ruleKey string) bool
+		// Sets kind of the Go type of the field which ruleSet is generated for
+		setKind(kind reflect.Kind)
+		// Returns kind of the Go type of the field, reflect.Invalid if it is unknown
+		getKind() reflect.Kind
 		// Replaces passed validator with existing deepValidator
 		setDeepValidator(input Validator)
 		// Returns deepValidator
@@ -164,7 +307,7 @@ type (
 		// Returns true if deepValidator is not nil
 		hasDeepValidator() bool
 		// Validates deepValidator
-		validateDeepValidator(ctx context.Context, input interface{}, translator Translator) interface{}
+		validateDeepValidator(ctx context.Context, input interface{}, translator Translator, withRules bool) interface{}
 		// Replaces passed validator with existing childrenValidator
 		setChildrenValidator(input Validator)
 		// Returns childrenValidator
@@ -172,11 +315,26 @@ type (
 		// Returns true if children is not nil
 		hasChildrenValidator() bool
 		// Validates childrenValidator
-		validateChildrenValidator(ctx context.Context, input interface{}, translator Translator) interface{}
+		validateChildrenValidator(ctx context.Context, input interface{}, translator Translator, withRules bool) interface{}
+		// Returns true if input has to get validated based on its dynamic type
+		isDynamic() bool
+		// Validates input by the validator which is registered for its dynamic type
+		validateDynamic(ctx context.Context, input interface{}, translator Translator, withRules bool) interface{}
 		// Returns requires
 		getRequires() requires
+		// Returns ruleSets which conditions choose based on passed parent
+		getBranches(ctx context.Context, parent interface{}) []branch
+		// Returns then or otherwise ruleSet of the last condition, returns nil if not defined yet
+		getBranch(name string) ruleSet
 		// Returns name
 		getName() string
+		// Returns errors which happened while building current ruleSet and ruleSets of its conditions
+		getErrors() []error
+		// Makes ruleSets of conditions and nested validators of current ruleSet sensitive if current ruleSet is sensitive
+		passSensitive()
+		// Returns keys of rules of current ruleSet and ruleSets of its conditions which have no specific message
+		// and hasMessage returns false for them
+		missingMessages(hasMessage func(ruleKey string) bool) []string
 		// Returns current validators + r.Validators
 		appendRuleSet(r ruleSet) ruleSet
 		// Returns passed argument name from struct if exist
@@ -184,7 +342,7 @@ type (
 		// Sets passed argument value instead of existing in name parameter if exists
 		set(name string, value interface{})
 		// Sets custom validators which are defined in generator
-		setGeneratorCustomValidators(validators *Validators) ruleSet
+		setGeneratorCustomValidators(customValidator func(key string) func(ctx context.Context, input interface{}) bool) ruleSet
 	}
 )
 
@@ -203,7 +361,7 @@ func (o *ruleSetS) Float() ruleSet {
 func (o *ruleSetS) Min(min float64) ruleSet {
 	functionName := "min"
 	o.validators[functionName] = minRule(min)
-	precision := determinePrecision(min)
+	precision := shared.DeterminePrecision(min)
 	o.addOption(functionName, "min", fmt.Sprintf("%."+precision+"f", min))
 	return o
 }
@@ -211,7 +369,7 @@ func (o *ruleSetS) Min(min float64) ruleSet {
 func (o *ruleSetS) Max(max float64) ruleSet {
 	functionName := "max"
 	o.validators[functionName] = maxRule(max)
-	precision := determinePrecision(max)
+	precision := shared.DeterminePrecision(max)
 	o.addOption(functionName, "max", fmt.Sprintf("%."+precision+"f", max))
 	return o
 }
@@ -232,6 +390,7 @@ func (o *ruleSetS) Len(length int) ruleSet {
 }
 
 func (o *ruleSetS) AlwaysCheckRules() ruleSet {
+	o.alwaysCheck = true
 	o.required()
 	return o
 }
@@ -239,7 +398,8 @@ func (o *ruleSetS) AlwaysCheckRules() ruleSet {
 func (o *ruleSetS) Required() ruleSet {
 	functionName := "required"
 	o.validators[functionName] = requiredRule
-	return o.AlwaysCheckRules()
+	o.required()
+	return o
 }
 
 func (o *ruleSetS) Optional() ruleSet {
@@ -250,19 +410,22 @@ func (o *ruleSetS) Optional() ruleSet {
 func (o *ruleSetS) NonZero() ruleSet {
 	functionName := "non_zero"
 	o.validators[functionName] = nonZeroRule
-	return o.AlwaysCheckRules()
+	o.required()
+	return o
 }
 
 func (o *ruleSetS) NonNil() ruleSet {
 	functionName := "non_nil"
 	o.validators[functionName] = nonNilRule
-	return o.AlwaysCheckRules()
+	o.required()
+	return o
 }
 
 func (o *ruleSetS) NonEmpty() ruleSet {
 	functionName := "non_empty"
 	o.validators[functionName] = nonEmptyRule
-	return o.AlwaysCheckRules()
+	o.required()
+	return o
 }
 
 func (o *ruleSetS) Email() ruleSet {
@@ -273,7 +436,12 @@ func (o *ruleSetS) Email() ruleSet {
 
 func (o *ruleSetS) Regex(pattern string) ruleSet {
 	functionName := "regex"
-	o.validators[functionName] = regexRule(pattern)
+	regex, err := compileRegex(pattern, o.regexTimeout)
+	if err != nil {
+		o.errors = append(o.errors, fmt.Errorf("invalid regex pattern %q: %s", pattern, err))
+		return o
+	}
+	o.validators[functionName] = regexRule(regex)
 	o.addOption(functionName, "pattern", pattern)
 	return o
 }
@@ -296,11 +464,10 @@ func (o *ruleSetS) Custom(validators Validators) ruleSet {
 
 func (o *ruleSetS) RegisteredCustom(validatorKeys ...string) ruleSet {
 	for _, key := range validatorKeys {
-		vs := *o.customValidators
 		if _, ok := o.validators[key]; ok {
 			panic(fmt.Sprintf("%s is duplicate and has to be unique", key))
 		}
-		if function, ok := vs[key]; ok {
+		if function := o.customValidator(key); function != nil {
 			o.validators[key] = function
 		} else {
 			panic(fmt.Sprintf("%s custom validator doesn't exist, it is really defined in generator?", key))
@@ -309,6 +476,32 @@ func (o *ruleSetS) RegisteredCustom(validatorKeys ...string) ruleSet {
 	return o
 }
 
+func (o *ruleSetS) Groups(ruleKey string, groups ...string) ruleSet {
+	if o.groups == nil {
+		o.groups = map[string][]string{}
+	}
+	o.groups[ruleKey] = append(o.groups[ruleKey], groups...)
+	return o
+}
+
+func (o *ruleSetS) Timeout(ruleKey string, timeout time.Duration) ruleSet {
+	if o.timeouts == nil {
+		o.timeouts = map[string]time.Duration{}
+	}
+	o.timeouts[ruleKey] = timeout
+	return o
+}
+
+func (o *ruleSetS) Async(ruleKeys ...string) ruleSet {
+	if o.async == nil {
+		o.async = map[string]bool{}
+	}
+	for _, key := range ruleKeys {
+		o.async[key] = true
+	}
+	return o
+}
+
 func (o *ruleSetS) Map() ruleSet {
 	functionName := "map"
 	o.validators[functionName] = mapRule
@@ -343,6 +536,26 @@ func (o *ruleSetS) Children(rule ruleSet) ruleSet {
 	return o
 }
 
+func (o *ruleSetS) OneOf(discriminator string, variants Variants) ruleSet {
+	functionName := "one_of"
+	validators := map[string]Validator{}
+	values := []string{}
+	for value, rules := range variants {
+		validators[value] = &validatorS{rule: nil, rules: rules}
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	discriminatorRule := &ruleSetS{name: discriminator, validators: Validators{functionName: oneOfRule(validators)}, requires: requires{}, options: options{}}
+	discriminatorRule.addOption(functionName, "choices", strings.ReplaceAll(fmt.Sprint(values), " ", ", "))
+	o.setDeepValidator(&validatorS{discriminatorRule: discriminatorRule, variants: validators})
+	return o
+}
+
+func (o *ruleSetS) Dynamic() ruleSet {
+	o.dynamic = true
+	return o
+}
+
 func (o *ruleSetS) Type(input interface{}) ruleSet {
 	functionName := "type"
 	switch v := input.(type) {
@@ -365,12 +578,18 @@ func (o *ruleSetS) Password() ruleSet {
 func (o *ruleSetS) OR(ruleSets ...ruleSet) ruleSet {
 	functionName := "or"
 	o.validators[functionName] = orRule(ruleSets...)
+	for _, r := range ruleSets {
+		o.errors = append(o.errors, r.getErrors()...)
+	}
 	return o
 }
 
 func (o *ruleSetS) XOR(ruleSets ...ruleSet) ruleSet {
 	functionName := "xor"
 	o.validators[functionName] = xorRule(ruleSets...)
+	for _, r := range ruleSets {
+		o.errors = append(o.errors, r.getErrors()...)
+	}
 	return o
 }
 
@@ -417,12 +636,53 @@ func (o *ruleSetS) WhenNotExistAll(choices ...string) ruleSet {
 	return o
 }
 
+func (o *ruleSetS) When(predicate Predicate) ruleSet {
+	o.conditions = append(o.conditions, &condition{predicate: predicate})
+	return o
+}
+
+func (o *ruleSetS) Then(rule ruleSet) ruleSet {
+	if len(o.conditions) == 0 {
+		panic("call When method before calling Then method")
+	}
+	c := o.conditions[len(o.conditions)-1]
+	if c.then == nil {
+		c.then = rule.Clone()
+	} else {
+		c.then.appendRuleSet(rule)
+	}
+	return o
+}
+
+func (o *ruleSetS) Otherwise(rule ruleSet) ruleSet {
+	if len(o.conditions) == 0 {
+		panic("call When method before calling Otherwise method")
+	}
+	c := o.conditions[len(o.conditions)-1]
+	if c.otherwise == nil {
+		c.otherwise = rule.Clone()
+	} else {
+		c.otherwise.appendRuleSet(rule)
+	}
+	return o
+}
+
 func (o *ruleSetS) String() ruleSet {
 	functionName := "string"
 	o.validators[functionName] = stringRule
 	return o
 }
 
+func (o *ruleSetS) AllowUnexported() ruleSet {
+	o.allowUnexported = true
+	return o
+}
+
+func (o *ruleSetS) Sensitive() ruleSet {
+	o.sensitive = true
+	return o
+}
+
 func (o *ruleSetS) GetValidator() Validator {
 	return o.deepValidator
 }
@@ -450,6 +710,24 @@ func (o *ruleSetS) getSpecificMessages() Messages {
 	return o.specificMessages
 }
 
+func (o *ruleSetS) SpecificMessageFuncs(specificMessageFuncs MessageFuncs) ruleSet {
+	o.specificMessageFuncs = specificMessageFuncs
+	return o
+}
+
+func (o *ruleSetS) getSpecificMessageFuncs() MessageFuncs {
+	return o.specificMessageFuncs
+}
+
+func (o *ruleSetS) Label(label string) ruleSet {
+	o.label = label
+	return o
+}
+
+func (o *ruleSetS) getLabel() string {
+	return o.label
+}
+
 func (o *ruleSetS) setChildrenValidator(input Validator) {
 	if o.childrenValidator != nil {
 		o.childrenValidator.getRule().appendRuleSet(input.getRule())
@@ -466,14 +744,65 @@ func (o *ruleSetS) hasChildrenValidator() bool {
 	return o.childrenValidator != nil
 }
 
-func (o *ruleSetS) validateChildrenValidator(ctx context.Context, input interface{}, translator Translator) interface{} {
-	return o.childrenValidator.Validate(ctx, input, translator)
+func (o *ruleSetS) validateChildrenValidator(ctx context.Context, input interface{}, translator Translator, withRules bool) interface{} {
+	return o.childrenValidator.validate(ctx, input, translator, withRules)
+}
+
+func (o *ruleSetS) isDynamic() bool {
+	return o.dynamic
+}
+
+func (o *ruleSetS) validateDynamic(ctx context.Context, input interface{}, translator Translator, withRules bool) interface{} {
+	if o.typeValidators == nil {
+		return nil
+	}
+	validator := o.typeValidators.get(input)
+	if validator == nil {
+		return nil
+	}
+	return validator.validate(ctx, input, translator, withRules)
 }
 
 func (o *ruleSetS) validate(ctx context.Context, input interface{}) []string {
 	fails := []string{}
-	for key, vFunction := range o.validators {
-		if !vFunction(ctx, input) {
+	// Sorted, so messages of failed rules always come in the same order
+	keys, _ := o.sortedKeys.Load().([]string)
+	if len(keys) != len(o.validators) {
+		keys = make([]string, 0, len(o.validators))
+		for key := range o.validators {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		o.sortedKeys.Store(keys)
+	}
+	async := asyncFromContext(ctx)
+	for _, key := range keys {
+		if ctx != nil && ctx.Err() != nil {
+			break
+		}
+		if !o.isActive(ctx, key) {
+			continue
+		}
+		vFunction := o.validators[key]
+		timedOut := false
+		run := func() bool {
+			if timeout, ok := o.timeouts[key]; ok {
+				var valid bool
+				valid, timedOut = timeoutRule(ctx, vFunction, input, timeout)
+				return valid
+			}
+			return vFunction(ctx, input)
+		}
+		var valid bool
+		if async != nil && o.async[key] {
+			valid = async.run(ctx, run)
+		} else {
+			valid = run()
+		}
+		if timedOut {
+			// Gets the message of timeout key, so it is not mistaken for input failing the rule
+			fails = append(fails, "timeout")
+		} else if !valid {
 			fails = append(fails, key)
 		}
 	}
@@ -504,10 +833,73 @@ func (o *ruleSetS) required() {
 	o.isOptional = false
 }
 
+func (o *ruleSetS) allowsUnexported() bool {
+	return o.allowUnexported
+}
+
+func (o *ruleSetS) isSensitive() bool {
+	return o.sensitive
+}
+
+func (o *ruleSetS) hasAsyncRules() bool {
+	if len(o.async) != 0 || o.dynamic {
+		return true
+	}
+	if o.deepValidator != nil && o.deepValidator.hasAsyncRules() {
+		return true
+	}
+	if o.childrenValidator != nil && o.childrenValidator.hasAsyncRules() {
+		return true
+	}
+	for _, c := range o.conditions {
+		if (c.then != nil && c.then.hasAsyncRules()) || (c.otherwise != nil && c.otherwise.hasAsyncRules()) {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *ruleSetS) isRequired() bool {
 	return !o.isOptional
 }
 
+func (o *ruleSetS) isRequiredIn(ctx context.Context) bool {
+	if !o.isRequired() {
+		return false
+	}
+	if o.alwaysCheck || len(o.groups) == 0 {
+		return true
+	}
+	for _, key := range []string{"required", "non_zero", "non_nil", "non_empty"} {
+		if _, ok := o.validators[key]; ok && o.isActive(ctx, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *ruleSetS) isActive(ctx context.Context, ruleKey string) bool {
+	groups, ok := o.groups[ruleKey]
+	if !ok || len(groups) == 0 {
+		return true
+	}
+	active := groupsFromContext(ctx)
+	for _, group := range groups {
+		if active[group] {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *ruleSetS) setKind(kind reflect.Kind) {
+	o.kind = kind
+}
+
+func (o *ruleSetS) getKind() reflect.Kind {
+	return o.kind
+}
+
 func (o *ruleSetS) setDeepValidator(input Validator) {
 	o.deepValidator = input
 }
@@ -520,18 +912,157 @@ func (o *ruleSetS) hasDeepValidator() bool {
 	return o.deepValidator != nil
 }
 
-func (o *ruleSetS) validateDeepValidator(ctx context.Context, input interface{}, translator Translator) interface{} {
-	return o.deepValidator.Validate(ctx, input, translator)
+func (o *ruleSetS) validateDeepValidator(ctx context.Context, input interface{}, translator Translator, withRules bool) interface{} {
+	return o.deepValidator.validate(ctx, input, translator, withRules)
 }
 
 func (o *ruleSetS) getRequires() requires {
 	return o.requires
 }
 
+func (o *ruleSetS) getBranches(ctx context.Context, parent interface{}) []branch {
+	branches := []branch{}
+	for _, c := range o.conditions {
+		if c.predicate(ctx, parent) {
+			if c.then != nil {
+				branches = append(branches, branch{name: "then", rule: c.then})
+			}
+		} else if c.otherwise != nil {
+			branches = append(branches, branch{name: "otherwise", rule: c.otherwise})
+		}
+	}
+
+	return branches
+}
+
+func (o *ruleSetS) getBranch(name string) ruleSet {
+	if len(o.conditions) == 0 {
+		panic(fmt.Sprintf("define a condition with when rule before using %s rules", name))
+	}
+	c := o.conditions[len(o.conditions)-1]
+	if name == "then" {
+		return c.then
+	}
+	return c.otherwise
+}
+
+func (o *ruleSetS) passSensitive() {
+	for _, c := range o.conditions {
+		for _, r := range []ruleSet{c.then, c.otherwise} {
+			if r == nil {
+				continue
+			}
+			if o.sensitive {
+				r.Sensitive()
+			}
+			r.passSensitive()
+		}
+	}
+	if o.childrenValidator != nil {
+		deepPassSensitive(o.childrenValidator, o.sensitive)
+	}
+	if o.deepValidator != nil {
+		deepPassSensitive(o.deepValidator, o.sensitive)
+	}
+}
+
 func (o *ruleSetS) getName() string {
 	return o.name
 }
 
+func (o *ruleSetS) Clone() ruleSet {
+	output := &ruleSetS{
+		name:            o.name,
+		label:           o.label,
+		isOptional:      o.isOptional,
+		alwaysCheck:     o.alwaysCheck,
+		allowUnexported: o.allowUnexported,
+		sensitive:       o.sensitive,
+		kind:            o.kind,
+		customValidator: o.customValidator,
+		customRules:     o.customRules,
+		dynamic:         o.dynamic,
+		typeValidators:  o.typeValidators,
+		regexTimeout:    o.regexTimeout,
+	}
+	output.validators = Validators{}
+	for key, value := range o.validators {
+		output.validators[key] = value
+	}
+	output.requires = requires{}
+	for key, value := range o.requires {
+		output.requires[key] = value
+	}
+	output.options = options{}
+	for key, value := range o.options {
+		output.options[key] = option{}
+		for subKey, subValue := range value {
+			output.options[key][subKey] = subValue
+		}
+	}
+	if o.specificMessages != nil {
+		output.specificMessages = Messages{}
+		for key, value := range o.specificMessages {
+			output.specificMessages[key] = value
+		}
+	}
+	if o.specificMessageFuncs != nil {
+		output.specificMessageFuncs = MessageFuncs{}
+		for key, value := range o.specificMessageFuncs {
+			output.specificMessageFuncs[key] = value
+		}
+	}
+	if o.groups != nil {
+		output.groups = map[string][]string{}
+		for key, value := range o.groups {
+			output.groups[key] = append([]string{}, value...)
+		}
+	}
+	if o.timeouts != nil {
+		output.timeouts = map[string]time.Duration{}
+		for key, value := range o.timeouts {
+			output.timeouts[key] = value
+		}
+	}
+	if o.async != nil {
+		output.async = map[string]bool{}
+		for key, value := range o.async {
+			output.async[key] = value
+		}
+	}
+	if o.deepValidator != nil {
+		output.deepValidator = o.deepValidator.Clone()
+	}
+	if o.childrenValidator != nil {
+		output.childrenValidator = o.childrenValidator.Clone()
+	}
+	for _, c := range o.conditions {
+		clone := &condition{predicate: c.predicate}
+		if c.then != nil {
+			clone.then = c.then.Clone()
+		}
+		if c.otherwise != nil {
+			clone.otherwise = c.otherwise.Clone()
+		}
+		output.conditions = append(output.conditions, clone)
+	}
+	output.errors = append([]error(nil), o.errors...)
+	return output
+}
+
+func (o *ruleSetS) getErrors() []error {
+	output := append([]error{}, o.errors...)
+	for _, c := range o.conditions {
+		if c.then != nil {
+			output = append(output, c.then.getErrors()...)
+		}
+		if c.otherwise != nil {
+			output = append(output, c.otherwise.getErrors()...)
+		}
+	}
+	return output
+}
+
 func (o *ruleSetS) appendRuleSet(r ruleSet) ruleSet {
 	rValidators := r.get("validators").(Validators)
 	for key, value := range rValidators {
@@ -556,13 +1087,48 @@ func (o *ruleSetS) appendRuleSet(r ruleSet) ruleSet {
 	for key, value := range rSpecificMessages {
 		o.specificMessages[key] = value
 	}
+	for key, value := range r.get("specificMessageFuncs").(MessageFuncs) {
+		if o.specificMessageFuncs == nil {
+			o.specificMessageFuncs = MessageFuncs{}
+		}
+		o.specificMessageFuncs[key] = value
+	}
 	if o.isOptional && !r.get("isOptional").(bool) {
 		o.isOptional = false
 	}
+	if r.get("alwaysCheck").(bool) {
+		o.alwaysCheck = true
+	}
+	for key, value := range r.get("groups").(map[string][]string) {
+		o.Groups(key, value...)
+	}
+	for key, value := range r.get("timeouts").(map[string]time.Duration) {
+		o.Timeout(key, value)
+	}
+	for key := range r.get("async").(map[string]bool) {
+		o.Async(key)
+	}
+	if r.get("allowUnexported").(bool) {
+		o.allowUnexported = true
+	}
+	if r.get("sensitive").(bool) {
+		o.sensitive = true
+	}
+	if r.get("dynamic").(bool) {
+		o.dynamic = true
+	}
+	o.conditions = append(o.conditions, r.get("conditions").([]*condition)...)
+	o.errors = append(o.errors, r.get("errors").([]error)...)
+	if rTypeValidators, ok := r.get("typeValidators").(*typeValidators); ok && rTypeValidators != nil && o.typeValidators == nil {
+		o.typeValidators = rTypeValidators
+	}
 	name := r.get("name").(string)
 	if name != "" && o.name == "" {
 		o.name = name
 	}
+	if label := r.get("label").(string); label != "" && o.label == "" {
+		o.label = label
+	}
 	rRequires := r.get("requires").(requires)
 	for key, value := range rRequires {
 		o.requires[key] = value
@@ -579,14 +1145,40 @@ func (o *ruleSetS) get(name string) interface{} {
 		return o.deepValidator
 	case "isOptional":
 		return o.isOptional
+	case "alwaysCheck":
+		return o.alwaysCheck
+	case "groups":
+		return o.groups
+	case "timeouts":
+		return o.timeouts
+	case "async":
+		return o.async
+	case "allowUnexported":
+		return o.allowUnexported
+	case "sensitive":
+		return o.sensitive
+	case "dynamic":
+		return o.dynamic
+	case "conditions":
+		return o.conditions
+	case "typeValidators":
+		return o.typeValidators
+	case "regexTimeout":
+		return o.regexTimeout
+	case "errors":
+		return o.errors
 	case "name":
 		return o.name
+	case "label":
+		return o.label
 	case "options":
 		return o.options
 	case "requires":
 		return o.requires
 	case "specificMessages":
 		return o.specificMessages
+	case "specificMessageFuncs":
+		return o.specificMessageFuncs
 	case "validators":
 		return o.validators
 	default:
@@ -602,22 +1194,49 @@ func (o *ruleSetS) set(name string, value interface{}) {
 		o.deepValidator = value.(Validator)
 	case "isOptional":
 		o.isOptional = value.(bool)
+	case "alwaysCheck":
+		o.alwaysCheck = value.(bool)
+	case "groups":
+		o.groups = value.(map[string][]string)
+	case "timeouts":
+		o.timeouts = value.(map[string]time.Duration)
+	case "async":
+		o.async = value.(map[string]bool)
+	case "allowUnexported":
+		o.allowUnexported = value.(bool)
+	case "sensitive":
+		o.sensitive = value.(bool)
+	case "dynamic":
+		o.dynamic = value.(bool)
+	case "conditions":
+		o.conditions = value.([]*condition)
+	case "typeValidators":
+		o.typeValidators = value.(*typeValidators)
+	case "regexTimeout":
+		o.regexTimeout = value.(time.Duration)
+	case "errors":
+		o.errors = value.([]error)
 	case "name":
 		o.name = value.(string)
+	case "label":
+		o.label = value.(string)
 	case "options":
 		o.options = value.(options)
 	case "requires":
 		o.requires = value.(requires)
 	case "specificMessages":
 		o.specificMessages = value.(Messages)
+	case "specificMessageFuncs":
+		o.specificMessageFuncs = value.(MessageFuncs)
 	case "validators":
 		o.validators = value.(Validators)
+		o.sortedKeys = atomic.Value{}
 	default:
 		panic(fmt.Sprintf("there is no item as %s", name))
 	}
 }
 
-func (o *ruleSetS) setGeneratorCustomValidators(validators *Validators) ruleSet {
-	o.customValidators = validators
+func (o *ruleSetS) setGeneratorCustomValidators(customValidator func(key string) func(ctx context.Context, input interface{}) bool) ruleSet {
+	o.customValidator = customValidator
 	return o
 }
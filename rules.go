@@ -2,10 +2,15 @@ package galidator
 
 import (
 	"context"
+	"fmt"
 	"net/mail"
 	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dlclark/regexp2"
+	"github.com/golodash/galidator/v2/internal/shared"
 	"github.com/golodash/godash/generals"
 	"github.com/nyaruka/phonenumbers"
 )
@@ -33,8 +38,10 @@ var defaultValidatorErrorMessages = map[string]string{
 	"or":        "ruleSets in $field did not pass based on or logic",
 	"xor":       "ruleSets in $field did not pass based on xor logic",
 	"choices":   "$value does not include in allowed choices: $choices",
+	"one_of":    "$field must be one of $choices",
 	"string":    "not a string",
 	"type":      "not a $type",
+	"timeout":   "$field could not be validated in time",
 
 	// Requires
 	"when_exist_one":     "$field is required because at least one of $choices fields are not nil, empty or zero(0, \"\", '')",
@@ -43,6 +50,62 @@ var defaultValidatorErrorMessages = map[string]string{
 	"when_not_exist_all": "$field is required because all of $choices fields are nil, empty or zero(0, \"\", '')",
 }
 
+// Maximum number of compiled regexes in regexCache, patterns beyond it get compiled without being cached
+const maxCachedRegexes = 1024
+
+var (
+	// Compiled regexes with their pattern and match timeout as key
+	regexCache sync.Map
+	// Number of compiled regexes in regexCache
+	cachedRegexes int64
+	// Used in passwordRule
+	passwordRegex = regexp2.MustCompile(shared.PasswordPattern, regexp2.None)
+	passwordMatch = regexRule(passwordRegex)
+	// Used in phoneRule
+	internationalPhoneRegex = regexp2.MustCompile(`^\+\d+$`, regexp2.None)
+)
+
+// Key of regexCache
+type regexCacheKey struct {
+	pattern string
+	timeout time.Duration
+}
+
+// Compiles passed pattern, compiled patterns are cached and shared between ruleSets up to maxCachedRegexes patterns
+//
+// If timeout is 0, matching never times out
+func compileRegex(pattern string, timeout time.Duration) (*regexp2.Regexp, error) {
+	key := regexCacheKey{pattern: pattern, timeout: timeout}
+	if regex, ok := regexCache.Load(key); ok {
+		return regex.(*regexp2.Regexp), nil
+	}
+
+	regex, err := regexp2.Compile(pattern, regexp2.None)
+	if err != nil {
+		return nil, err
+	}
+	if timeout != 0 {
+		regex.MatchTimeout = timeout
+	}
+	if atomic.LoadInt64(&cachedRegexes) >= maxCachedRegexes {
+		return regex, nil
+	}
+	actual, loaded := regexCache.LoadOrStore(key, regex)
+	if !loaded {
+		atomic.AddInt64(&cachedRegexes, 1)
+	}
+	return actual.(*regexp2.Regexp), nil
+}
+
+// Returns a copy of default error messages of rules and requires with their keys as keys
+func DefaultMessages() Messages {
+	output := Messages{}
+	for key, value := range defaultValidatorErrorMessages {
+		output[key] = value
+	}
+	return output
+}
+
 func isValid(input interface{}) bool {
 	return reflect.ValueOf(input).IsValid()
 }
@@ -92,7 +155,7 @@ func minRule(min float64) func(context.Context, interface{}) bool {
 		}
 		inputValue := reflect.ValueOf(input)
 		switch inputValue.Kind() {
-		case reflect.String, reflect.Map, reflect.Slice:
+		case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
 			return inputValue.Len() >= int(min)
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
 			reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
@@ -112,7 +175,7 @@ func maxRule(max float64) func(context.Context, interface{}) bool {
 		}
 		inputValue := reflect.ValueOf(input)
 		switch inputValue.Kind() {
-		case reflect.String, reflect.Map, reflect.Slice:
+		case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
 			return inputValue.Len() <= int(max)
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
 			reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
@@ -135,7 +198,7 @@ func lenRangeRule(from, to int) func(context.Context, interface{}) bool {
 		}
 		inputValue := reflect.ValueOf(input)
 		switch inputValue.Kind() {
-		case reflect.String, reflect.Map, reflect.Slice:
+		case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
 			if from != -1 && inputValue.Len() < from {
 				return false
 			} else if to != -1 && inputValue.Len() > to {
@@ -156,7 +219,7 @@ func lenRule(length int) func(context.Context, interface{}) bool {
 		}
 		inputValue := reflect.ValueOf(input)
 		switch inputValue.Kind() {
-		case reflect.String, reflect.Map, reflect.Slice:
+		case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
 			return inputValue.Len() == length
 		default:
 			return false
@@ -209,17 +272,51 @@ func phoneRule(ctx context.Context, input interface{}) bool {
 	if !isValid(input) {
 		return false
 	}
-	InternationalPhoneRegex := regexp2.MustCompile(`^\+\d+$`, regexp2.None)
-	if ok, err := InternationalPhoneRegex.MatchString(input.(string)); !ok || err != nil {
+	if ok, err := internationalPhoneRegex.MatchString(input.(string)); !ok || err != nil {
 		return false
 	}
 	parsedNumber, err := phonenumbers.Parse(input.(string), "")
 	return err == nil && phonenumbers.IsValidNumber(parsedNumber)
 }
 
-// Returns true if input matches the passed pattern
-func regexRule(pattern string) func(context.Context, interface{}) bool {
-	regex := regexp2.MustCompile(pattern, regexp2.None)
+// Runs passed rule with a context which gets canceled after timeout
+//
+// Returns true as timedOut if rule does not return before timeout, a panic in rule gets raised again in the caller goroutine
+func timeoutRule(ctx context.Context, rule func(context.Context, interface{}) bool, input interface{}, timeout time.Duration) (valid bool, timedOut bool) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		valid     bool
+		recovered interface{}
+	}
+	results := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				results <- result{recovered: r}
+			}
+		}()
+		results <- result{valid: rule(ctx, input)}
+	}()
+	select {
+	case output := <-results:
+		if output.recovered != nil {
+			panic(output.recovered)
+		}
+		return output.valid, false
+	case <-ctx.Done():
+		return false, true
+	}
+}
+
+// Returns true if input matches the passed regex
+//
+// If matching times out, input does not match
+func regexRule(regex *regexp2.Regexp) func(context.Context, interface{}) bool {
 	return func(ctx context.Context, input interface{}) bool {
 		if !isValid(input) {
 			return false
@@ -227,7 +324,7 @@ func regexRule(pattern string) func(context.Context, interface{}) bool {
 		inputValue := reflect.ValueOf(input)
 		switch inputValue.Kind() {
 		case reflect.String:
-			output, _ := regex.MatchString(input.(string))
+			output, _ := regex.MatchString(inputValue.String())
 			return output
 		default:
 			return false
@@ -251,20 +348,18 @@ func structRule(ctx context.Context, input interface{}) bool {
 	return reflect.TypeOf(input).Kind() == reflect.Struct
 }
 
-// Returns true if input is a slice
+// Returns true if input is a slice or an array
 func sliceRule(ctx context.Context, input interface{}) bool {
 	if !isValid(input) {
 		return false
 	}
-	return reflect.TypeOf(input).Kind() == reflect.Slice
+	kind := reflect.TypeOf(input).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
 }
 
 // Returns true if input is at least 8 characters long, has one lowercase, one uppercase, one special and one number character
 func passwordRule(ctx context.Context, input interface{}) bool {
-	if !isValid(input) {
-		return false
-	}
-	return regexRule("^(?=.*[a-z])(?=.*[A-Z])(?=.*\\d)(?=.*[ !\"#$%&'()*+,-.\\/:;<=>?@[\\]^_`{|}~])[A-Za-z\\d !\"#$%&'()*+,-.\\/:;<=>?@[\\]^_`{|}~]{8,}$")(ctx, input)
+	return passwordMatch(ctx, input)
 }
 
 // If at least one of the passed ruleSets pass, this rule will pass
@@ -315,6 +410,17 @@ func choicesRule(choices ...interface{}) func(context.Context, interface{}) bool
 	}
 }
 
+// Returns true if input is one of the keys of passed variants
+func oneOfRule(variants map[string]Validator) func(context.Context, interface{}) bool {
+	return func(ctx context.Context, input interface{}) bool {
+		if !isValid(input) {
+			return false
+		}
+		_, ok := variants[fmt.Sprint(input)]
+		return ok
+	}
+}
+
 // Returns true if input is string
 func stringRule(ctx context.Context, input interface{}) bool {
 	if !isValid(input) {
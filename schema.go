@@ -0,0 +1,189 @@
+package galidator
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+var (
+	// Keys of rules which decide the JSON Schema type of a ruleSet
+	schemaTypes = map[string]string{
+		"int":    "integer",
+		"float":  "number",
+		"string": "string",
+		"map":    "object",
+		"struct": "object",
+		"slice":  "array",
+	}
+
+	// JSON Schema types of kinds of Go types, used to choose keywords of ruleSets without a type rule
+	schemaKinds = map[reflect.Kind]string{
+		reflect.Int:     "integer",
+		reflect.Int8:    "integer",
+		reflect.Int16:   "integer",
+		reflect.Int32:   "integer",
+		reflect.Int64:   "integer",
+		reflect.Uint:    "integer",
+		reflect.Uint8:   "integer",
+		reflect.Uint16:  "integer",
+		reflect.Uint32:  "integer",
+		reflect.Uint64:  "integer",
+		reflect.Float32: "number",
+		reflect.Float64: "number",
+		reflect.String:  "string",
+		reflect.Map:     "object",
+		reflect.Struct:  "object",
+		reflect.Slice:   "array",
+		reflect.Array:   "array",
+	}
+
+	// JSON Schema keywords which bound values or lengths with the type they apply to
+	schemaKeywordTypes = map[string]string{
+		"minimum":       "number",
+		"maximum":       "number",
+		"minLength":     "string",
+		"maxLength":     "string",
+		"minItems":      "array",
+		"maxItems":      "array",
+		"minProperties": "object",
+		"maxProperties": "object",
+	}
+)
+
+func (o *validatorS) JSONSchema() map[string]interface{} {
+	switch {
+	case o.variants != nil:
+		return o.variantsSchema()
+	case o.rules != nil:
+		return rulesSchema(o.rules)
+	case o.rule != nil:
+		return ruleSetSchema(o.rule)
+	}
+	return map[string]interface{}{}
+}
+
+// Returns a `oneOf` schema with one object schema per variant, the discriminator property of every variant
+// is required and has its value as `const`
+func (o *validatorS) variantsSchema() map[string]interface{} {
+	discriminator := o.discriminatorRule.getName()
+	values := make([]string, 0, len(o.variants))
+	for value := range o.variants {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	oneOf := []interface{}{}
+	for _, value := range values {
+		schema := o.variants[value].JSONSchema()
+		schema["type"] = "object"
+		properties, _ := schema["properties"].(map[string]interface{})
+		if properties == nil {
+			properties = map[string]interface{}{}
+			schema["properties"] = properties
+		}
+		properties[discriminator] = map[string]interface{}{"const": value}
+		required, _ := schema["required"].([]string)
+		schema["required"] = append([]string{discriminator}, required...)
+		oneOf = append(oneOf, schema)
+	}
+	return map[string]interface{}{
+		"type":          "object",
+		"oneOf":         oneOf,
+		"discriminator": map[string]interface{}{"propertyName": discriminator},
+	}
+}
+
+// Returns an object schema with a property for every rule, names of required rules are listed in `required`
+func rulesSchema(rules Rules) map[string]interface{} {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	properties := map[string]interface{}{}
+	required := []string{}
+	for _, key := range keys {
+		name := key
+		if rules[key].getName() != "" {
+			name = rules[key].getName()
+		}
+		properties[name] = ruleSetSchema(rules[key])
+		if rules[key].isRequired() {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) != 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// Returns schema of passed ruleSet, rules which have no JSON Schema equivalent (like custom rules) are left out
+//
+// Length rules of ruleSets without a type rule get exported with the keyword of kind of the Go type of the field,
+// if the kind is unknown (like in Rules), they get exported for strings, arrays and numbers at once, because
+// JSON Schema keywords only apply to values of their own type
+func ruleSetSchema(r ruleSet) map[string]interface{} {
+	schema := map[string]interface{}{}
+	if r.hasDeepValidator() {
+		schema = r.getDeepValidator().JSONSchema()
+	}
+	validators, _ := r.get("validators").(Validators)
+	typ, _ := schema["type"].(string)
+	for key := range validators {
+		if t, ok := schemaTypes[key]; ok {
+			typ = t
+		}
+	}
+	if r.hasChildrenValidator() {
+		typ = "array"
+		schema["items"] = r.getChildrenValidator().JSONSchema()
+	}
+	if typ != "" {
+		schema["type"] = typ
+	}
+	if label := r.getLabel(); label != "" {
+		schema["title"] = label
+	}
+	// Type which chooses keywords, it does not appear in the schema if there is no type rule
+	keywordsType := typ
+	if keywordsType == "" {
+		keywordsType = schemaKinds[r.getKind()]
+	}
+
+	// Sets passed keywords which apply to type of the schema to passed number, negative lengths mean no limit
+	bound := func(value string, keywords ...string) {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return
+		}
+		for _, keyword := range keywords {
+			keywordType := schemaKeywordTypes[keyword]
+			if (keywordsType == "" || keywordsType == keywordType || keywordType == "number" && keywordsType == "integer") && (number >= 0 || keywordType == "number") {
+				schema[keyword] = number
+			}
+		}
+	}
+	for key := range validators {
+		option := r.getOption(key)
+		switch key {
+		case "min":
+			bound(option["min"], "minimum", "minLength", "minItems", "minProperties")
+		case "max":
+			bound(option["max"], "maximum", "maxLength", "maxItems", "maxProperties")
+		case "len":
+			bound(option["length"], "minLength", "minItems", "minProperties", "maxLength", "maxItems", "maxProperties")
+		case "len_range":
+			bound(option["from"], "minLength", "minItems", "minProperties")
+			bound(option["to"], "maxLength", "maxItems", "maxProperties")
+		case "regex":
+			schema["pattern"] = option["pattern"]
+		case "email":
+			schema["format"] = "email"
+		}
+	}
+	return schema
+}
@@ -0,0 +1,356 @@
+package galidator
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// Symbols which numbers get formatted with in a locale
+	numberFormat struct {
+		// Separates integer part from fraction part
+		decimal string
+		// Separates groups of three digits in integer part
+		group string
+		// Digits from 0 to 9, empty means ASCII digits
+		digits []rune
+	}
+
+	// Returns plural category of a number, like `one` or `other`
+	//
+	// n is absolute value of the number, i is its integer part and v is number of its visible fraction digits
+	pluralRule func(n float64, i int64, v int) string
+
+	// Renders a message template, see getFormattedErrorMessage
+	templateRenderer struct {
+		// Values of placeholders
+		params option
+		// Locale which numbers and plural forms get formatted for
+		locale string
+	}
+)
+
+var (
+	// Escapes characters which have a meaning in templates, see EscapeMessage
+	messageEscaper = strings.NewReplacer("$", "$$", "{", "${", "}", "$}", "#", "$#")
+
+	// Matches numbers which formatNumber formats, like `-1234.5`
+	plainNumberPattern = regexp.MustCompile(`^[+-]?\d+(\.\d+)?$`)
+
+	// Number formats of locales, locales which are not here use number format of `en`
+	numberFormats = map[string]numberFormat{
+		"en": {decimal: ".", group: ","},
+		"zh": {decimal: ".", group: ","},
+		"de": {decimal: ",", group: "."},
+		"es": {decimal: ",", group: "."},
+		"fr": {decimal: ",", group: " "},
+		"ru": {decimal: ",", group: " "},
+		"fa": {decimal: "٫", group: "٬", digits: []rune("۰۱۲۳۴۵۶۷۸۹")},
+		"ar": {decimal: "٫", group: "٬", digits: []rune("٠١٢٣٤٥٦٧٨٩")},
+	}
+
+	// Plural rules of locales based on CLDR, locales which are not here use plural rule of `en`
+	pluralRules = map[string]pluralRule{
+		"en": oneOtherPlural,
+		"de": oneOtherPlural,
+		"es": oneOtherPlural,
+		"zh": func(n float64, i int64, v int) string {
+			return "other"
+		},
+		"fr": func(n float64, i int64, v int) string {
+			if i == 0 || i == 1 {
+				return "one"
+			}
+			return "other"
+		},
+		"fa": func(n float64, i int64, v int) string {
+			if i == 0 || n == 1 {
+				return "one"
+			}
+			return "other"
+		},
+		"ru": func(n float64, i int64, v int) string {
+			if v != 0 {
+				return "other"
+			}
+			switch {
+			case i%10 == 1 && i%100 != 11:
+				return "one"
+			case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+				return "few"
+			default:
+				return "many"
+			}
+		},
+		"ar": func(n float64, i int64, v int) string {
+			if v != 0 || n != math.Trunc(n) {
+				return "other"
+			}
+			switch {
+			case i == 0:
+				return "zero"
+			case i == 1:
+				return "one"
+			case i == 2:
+				return "two"
+			case i%100 >= 3 && i%100 <= 10:
+				return "few"
+			case i%100 >= 11 && i%100 <= 99:
+				return "many"
+			default:
+				return "other"
+			}
+		},
+	}
+)
+
+// Plural rule of languages which only have `one` and `other` forms, like English
+func oneOtherPlural(n float64, i int64, v int) string {
+	if i == 1 && v == 0 {
+		return "one"
+	}
+	return "other"
+}
+
+// Returns the locale which has passed condition for passed locale, like `fa` for `fa-IR`, falls back to DefaultLocale
+func localeIn(locale string, has func(locale string) bool) string {
+	locale = normalizeLocale(locale)
+	if has(locale) {
+		return locale
+	}
+	if index := strings.Index(locale, "-"); index != -1 && has(locale[:index]) {
+		return locale[:index]
+	}
+	return DefaultLocale
+}
+
+// Returns number format of passed locale
+func numberFormatOf(locale string) numberFormat {
+	return numberFormats[localeIn(locale, func(locale string) bool {
+		_, ok := numberFormats[locale]
+		return ok
+	})]
+}
+
+// Returns plural rule of passed locale
+func pluralRuleOf(locale string) pluralRule {
+	return pluralRules[localeIn(locale, func(locale string) bool {
+		_, ok := pluralRules[locale]
+		return ok
+	})]
+}
+
+// Formats passed number with symbols of passed locale, like `1.234,5` for `1234.5` in `de`
+//
+// Passed input is returned as it is if it is not a plain decimal number, like `1e+21` or `Inf`
+func formatNumber(input string, locale string) string {
+	if !plainNumberPattern.MatchString(input) {
+		return input
+	}
+	format := numberFormatOf(locale)
+
+	sign := ""
+	if strings.HasPrefix(input, "-") || strings.HasPrefix(input, "+") {
+		sign, input = input[:1], input[1:]
+	}
+	integer, fraction := input, ""
+	if index := strings.Index(input, "."); index != -1 {
+		integer, fraction = input[:index], input[index+1:]
+	}
+
+	output := strings.Builder{}
+	output.WriteString(sign)
+	for i, digit := range integer {
+		if i != 0 && (len(integer)-i)%3 == 0 {
+			output.WriteString(format.group)
+		}
+		output.WriteString(localizeDigit(digit, format))
+	}
+	if fraction != "" {
+		output.WriteString(format.decimal)
+		for _, digit := range fraction {
+			output.WriteString(localizeDigit(digit, format))
+		}
+	}
+	return output.String()
+}
+
+// Returns passed ASCII digit in digits of passed number format
+func localizeDigit(digit rune, format numberFormat) string {
+	if format.digits != nil && digit >= '0' && digit <= '9' {
+		return string(format.digits[digit-'0'])
+	}
+	return string(digit)
+}
+
+// Returns plural category of passed number in passed locale, returns `other` if input is not a number
+func pluralCategory(input string, locale string) string {
+	n, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return "other"
+	}
+	n = math.Abs(n)
+	v := 0
+	if index := strings.Index(input, "."); index != -1 {
+		v = len(input) - index - 1
+	}
+	return pluralRuleOf(locale)(n, int64(n), v)
+}
+
+// Returns passed text escaped for error messages, so `$`, `{`, `}` and `#` in it appear as they are
+// instead of starting placeholders or blocks
+//
+// Use it for runtime data which message functions put in their messages
+func EscapeMessage(text string) string {
+	return messageEscaper.Replace(text)
+}
+
+// Renders passed template
+func (o *templateRenderer) render(template string) string {
+	output := strings.Builder{}
+	o.renderTo(&output, template, "")
+	return output.String()
+}
+
+// Renders passed template into output, number replaces `#` if it is not empty (in branches of plural blocks)
+func (o *templateRenderer) renderTo(output *strings.Builder, template string, number string) {
+	for i := 0; i < len(template); {
+		switch c := template[i]; {
+		case c == '$':
+			if i+1 < len(template) && strings.IndexByte("${}#", template[i+1]) != -1 {
+				output.WriteByte(template[i+1])
+				i += 2
+				continue
+			}
+			if name := o.placeholder(template[i+1:]); name != "" {
+				output.WriteString(o.params[name])
+				i += 1 + len(name)
+				continue
+			}
+		case c == '#' && number != "":
+			output.WriteString(formatNumber(number, o.locale))
+			i++
+			continue
+		case c == '{':
+			if length, ok := o.renderBlock(output, template[i:]); ok {
+				i += length
+				continue
+			}
+		}
+		output.WriteByte(template[i])
+		i++
+	}
+}
+
+// Returns the longest name of params which passed text starts with, returns an empty string if there is none
+func (o *templateRenderer) placeholder(text string) string {
+	longest := ""
+	for name := range o.params {
+		if len(name) > len(longest) && strings.HasPrefix(text, name) {
+			longest = name
+		}
+	}
+	return longest
+}
+
+// Renders the block which passed text starts with into output and returns length of the block and true
+//
+// Blocks are `{name, number}`, `{name, plural, =0 {...} one {...} other {...}}` and `{name, select, value {...} other {...}}`
+//
+// Returns false and renders nothing if passed text does not start with a valid block
+func (o *templateRenderer) renderBlock(output *strings.Builder, text string) (int, bool) {
+	end := matchingBrace(text)
+	if end == -1 {
+		return 0, false
+	}
+	body := text[1:end]
+	parts := strings.SplitN(body, ",", 3)
+	if len(parts) < 2 {
+		return 0, false
+	}
+	name := strings.TrimSpace(parts[0])
+	value, ok := o.params[name]
+	if !ok {
+		return 0, false
+	}
+
+	switch kind := strings.TrimSpace(parts[1]); {
+	case kind == "number" && len(parts) == 2:
+		output.WriteString(formatNumber(value, o.locale))
+	case (kind == "plural" || kind == "select") && len(parts) == 3:
+		branches, ok := parseBranches(parts[2])
+		if !ok {
+			return 0, false
+		}
+		number := ""
+		key := value
+		if kind == "plural" {
+			number = value
+			key = pluralCategory(value, o.locale)
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				for selector := range branches {
+					if exact, err := strconv.ParseFloat(strings.TrimPrefix(selector, "="), 64); strings.HasPrefix(selector, "=") && err == nil && exact == n {
+						key = selector
+					}
+				}
+			}
+		}
+		branch, ok := branches[key]
+		if !ok {
+			branch = branches["other"]
+		}
+		o.renderTo(output, branch, number)
+	default:
+		return 0, false
+	}
+	return end + 1, true
+}
+
+// Parses `selector {text} selector {text} ...` and returns texts with their selectors as keys
+func parseBranches(text string) (map[string]string, bool) {
+	branches := map[string]string{}
+	for text = strings.TrimSpace(text); text != ""; text = strings.TrimSpace(text) {
+		index := strings.IndexFunc(text, func(r rune) bool {
+			return r == '{' || unicode.IsSpace(r)
+		})
+		if index <= 0 {
+			return nil, false
+		}
+		selector := text[:index]
+		text = strings.TrimSpace(text[index:])
+		end := matchingBrace(text)
+		if end == -1 {
+			return nil, false
+		}
+		branches[selector] = text[1:end]
+		text = text[end+1:]
+	}
+	return branches, len(branches) != 0
+}
+
+// Returns index of the brace which closes the brace at the start of passed text, returns -1 if there is none
+//
+// Braces which are escaped by `$` are ignored
+func matchingBrace(text string) int {
+	if !strings.HasPrefix(text, "{") {
+		return -1
+	}
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '$':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"testing"
+)
+
+type arrayPoint struct {
+	Coordinates [3]float64 `json:"coordinates" g:"c.min=-90,c.max=90" c.min:"$value is lower than $min" c.max:"$value is higher than $max"`
+	Hash        [4]byte    `json:"hash" g:"non_zero" non_zero:"$field can not be zero"`
+}
+
+type arrayUser struct {
+	Name string `json:"name" g:"required" required:"$field is required"`
+}
+
+type arrayUsers struct {
+	Users []*arrayUser `json:"users"`
+}
+
+func TestArray(t *testing.T) {
+	scenarios := []scenario{
+		{
+			name:      "pass-len",
+			validator: g.Validator(g.R().Len(3)),
+			in:        [3]int{1, 2, 3},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-len",
+			validator: g.Validator(g.R().Len(2)),
+			in:        [3]int{1, 2, 3},
+			panic:     false,
+			expected:  []string{"'s length must be equal to 2"},
+		},
+		{
+			name:      "pass-slice-min-max-len-range",
+			validator: g.Validator(g.R().Slice().Min(1).Max(4).LenRange(2, 3)),
+			in:        [3]int{1, 2, 3},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "pass-children",
+			validator: g.Validator(arrayPoint{}),
+			in:        arrayPoint{Coordinates: [3]float64{12.5, -45, 0}, Hash: [4]byte{1, 2, 3, 4}},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-children",
+			validator: g.Validator(arrayPoint{}),
+			in:        arrayPoint{Coordinates: [3]float64{12.5, -91, 91}, Hash: [4]byte{}},
+			panic:     false,
+			expected: map[string]interface{}{
+				"coordinates": map[string][]string{"1": {"-91 is lower than -90"}, "2": {"91 is higher than 90"}},
+				"hash":        []string{"hash can not be zero"},
+			},
+		},
+		{
+			name:      "pass-pointer-elements",
+			validator: g.Validator(arrayUsers{}),
+			in:        arrayUsers{Users: []*arrayUser{{Name: "1"}, {Name: "2"}}},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-pointer-elements",
+			validator: g.Validator(arrayUsers{}),
+			in:        arrayUsers{Users: []*arrayUser{{Name: "1"}, {Name: ""}, nil}},
+			panic:     false,
+			expected:  map[string]map[string]interface{}{"users": {"1": map[string][]string{"name": {"name is required"}}, "2": []string{"can not be nil"}}},
+		},
+		{
+			name:      "fail-pointer-elements-array",
+			validator: g.Validator([2]*arrayUser{}),
+			in:        [2]*arrayUser{nil, {Name: ""}},
+			panic:     false,
+			expected:  map[string]interface{}{"0": []string{"can not be nil"}, "1": map[string][]string{"name": {"name is required"}}},
+		},
+		{
+			name:      "fail-nil-input",
+			validator: g.Validator(arrayUser{}),
+			in:        (*arrayUser)(nil),
+			panic:     false,
+			expected:  []string{"can not be nil"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type asyncOrder struct {
+	Username string      `json:"username"`
+	Coupon   string      `json:"coupon"`
+	Items    []asyncItem `json:"items"`
+	Note     string      `json:"note"`
+	Tags     []string    `json:"tags"`
+}
+
+type asyncItem struct {
+	SKU string `json:"sku"`
+}
+
+func TestAsync(t *testing.T) {
+	var running, maxRunning int64
+	exists := func(ctx context.Context, input interface{}) bool {
+		current := atomic.AddInt64(&running, 1)
+		defer atomic.AddInt64(&running, -1)
+		for {
+			max := atomic.LoadInt64(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt64(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return input != "taken"
+	}
+	ag := galidator.New().CustomValidators(galidator.Validators{"exists": exists})
+	v := ag.ComplexValidator(galidator.Rules{
+		"Username": ag.R("username").Required().RegisteredCustom("exists").Async("exists").SpecificMessages(galidator.Messages{"exists": "$field is taken"}),
+		"Coupon":   ag.R("coupon").RegisteredCustom("exists").Async("exists").SpecificMessages(galidator.Messages{"exists": "$field is used"}),
+		"Items": ag.R("items").Slice().Children(ag.R().Complex(galidator.Rules{
+			"SKU": ag.R("sku").RegisteredCustom("exists").Async("exists").SpecificMessages(galidator.Messages{"exists": "$field is unknown"}),
+		})),
+		"Note": ag.R("note").String().Len(3),
+		"Tags": ag.R("tags").Children(ag.R().RegisteredCustom("exists").Async("exists").SpecificMessages(galidator.Messages{"exists": "$value is taken"})),
+	})
+	input := asyncOrder{
+		Username: "taken",
+		Coupon:   "free",
+		Items:    []asyncItem{{SKU: "a"}, {SKU: "taken"}, {SKU: "b"}, {SKU: "taken"}},
+		Note:     "abcd",
+		Tags:     []string{"x", "taken", "y", "z"},
+	}
+	expected := map[string]interface{}{
+		"username": []string{"username is taken"},
+		"items": map[string]interface{}{
+			"1": map[string]interface{}{"sku": []string{"sku is unknown"}},
+			"3": map[string]interface{}{"sku": []string{"sku is unknown"}},
+		},
+		"note": []string{"note's length must be equal to 3"},
+		"tags": map[string]interface{}{"1": []string{"taken is taken"}},
+	}
+
+	sequential := v.Validate(context.TODO(), input)
+	check(t, expected, sequential)
+	check(t, int64(1), atomic.LoadInt64(&maxRunning))
+
+	atomic.StoreInt64(&maxRunning, 0)
+	concurrent := v.Validate(galidator.WithAsync(context.TODO(), 0), input)
+	check(t, expected, concurrent)
+	if max := atomic.LoadInt64(&maxRunning); max < 2 {
+		t.Errorf("expected rules to run at the same time, got %d at most", max)
+	}
+
+	atomic.StoreInt64(&maxRunning, 0)
+	limited := v.Validate(galidator.WithAsync(context.TODO(), 2), input)
+	check(t, expected, limited)
+	check(t, int64(2), atomic.LoadInt64(&maxRunning))
+
+	t.Run("panic", func(t *testing.T) {
+		defer deferTestCases(t, true, nil)
+		v.Validate(galidator.WithAsync(context.TODO(), 0), map[string]interface{}{"Username": "free"})
+	})
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type batchRecord struct {
+	Name string `json:"name" g:"required,len_range=3&10" required:"$field is required" len_range:"$field has a wrong length"`
+}
+
+func TestValidateBatch(t *testing.T) {
+	v := g.Validator(batchRecord{})
+	records := []batchRecord{{Name: "first"}, {Name: "ab"}, {Name: "third"}, {}, {Name: "fifth"}}
+	canceled, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	t.Run("slice", func(t *testing.T) {
+		result := v.ValidateBatch(context.TODO(), records, galidator.BatchOptions{Workers: 3})
+		if result.Total != 5 || result.Valid != 3 || result.Invalid != 2 || result.Skipped != 0 || result.BudgetExceeded || result.Err != nil {
+			t.Errorf("wrong summary: %+v", result)
+		}
+		for i, record := range result.Records {
+			if record.Index != i {
+				t.Errorf("expected index %d, got %d", i, record.Index)
+			}
+		}
+		check(t, map[string][]string{"name": {"name has a wrong length"}}, result.Records[1].Errors)
+		check(t, map[string][]string{"name": {"name has a wrong length", "name is required"}}, normalizeErrors(result.Records[3].Errors))
+		if failed := result.Failed(); len(failed) != 2 || failed[0].Index != 1 || failed[1].Index != 3 {
+			t.Errorf("wrong failed records: %+v", failed)
+		}
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		inputs := make(chan *batchRecord)
+		go func() {
+			defer close(inputs)
+			for i := range records {
+				inputs <- &records[i]
+			}
+		}()
+		result := v.ValidateBatch(context.TODO(), inputs)
+		if result.Total != 5 || result.Valid != 3 || result.Invalid != 2 || len(result.Records) != 5 {
+			t.Errorf("wrong summary: %+v", result)
+		}
+		check(t, map[string][]string{"name": {"name has a wrong length"}}, result.Records[1].Errors)
+	})
+
+	t.Run("error-budget", func(t *testing.T) {
+		result := v.ValidateBatch(context.TODO(), append(records, records...), galidator.BatchOptions{Workers: 1, ErrorBudget: 1})
+		if !result.BudgetExceeded || result.Invalid != 2 || result.Total != 10 || result.Skipped != 10-len(result.Records) || result.Skipped == 0 {
+			t.Errorf("wrong summary: %+v", result)
+		}
+		result = v.ValidateBatch(context.TODO(), records, galidator.BatchOptions{Workers: 1, ErrorBudget: 2})
+		if result.BudgetExceeded || result.Invalid != 2 || result.Skipped != 0 {
+			t.Errorf("wrong summary: %+v", result)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		panicking := g.Validator(g.R().Custom(galidator.Validators{"boom": func(ctx context.Context, input interface{}) bool {
+			panic("boom")
+		}}))
+		defer func() {
+			check(t, "boom", recover())
+		}()
+		panicking.ValidateBatch(context.TODO(), []string{"a", "b", "c"}, galidator.BatchOptions{Workers: 2})
+		t.Errorf("expected a panic")
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		result := v.ValidateBatch(canceled, records)
+		if result.Err != context.Canceled || result.Total != 5 || result.Skipped != 5-len(result.Records) {
+			t.Errorf("wrong summary: %+v", result)
+		}
+	})
+
+	t.Run("translator", func(t *testing.T) {
+		result := v.ValidateBatch(context.TODO(), records[1:2], galidator.BatchOptions{Translator: func(s string) string { return "translated " + s }})
+		check(t, map[string][]string{"name": {"translated name has a wrong length"}}, result.Records[0].Errors)
+	})
+
+	t.Run("invalid-inputs", func(t *testing.T) {
+		defer deferTestCases(t, true, nil)
+		v.ValidateBatch(context.TODO(), batchRecord{})
+	})
+}
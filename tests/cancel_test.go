@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golodash/galidator/v2"
+)
+
+func TestCancel(t *testing.T) {
+	calls := 0
+	cg := galidator.New().CustomValidators(galidator.Validators{
+		"slow": func(ctx context.Context, input interface{}) bool {
+			calls++
+			select {
+			case <-time.After(20 * time.Millisecond):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		},
+	})
+	v := cg.Validator(cg.R().Slice().Children(cg.R().RegisteredCustom("slow")))
+	elements := make([]interface{}, 50)
+	for i := range elements {
+		elements[i] = i + 1
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		output := v.Validate(ctx, elements)
+		if !galidator.IsCanceled(output) || !errors.Is(output.(error), context.Canceled) {
+			t.Errorf("expected a canceled error, got %v", output)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		calls = 0
+		ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		output := v.Validate(ctx, elements)
+		if !galidator.IsCanceled(output) || !errors.Is(output.(error), context.DeadlineExceeded) {
+			t.Errorf("expected a canceled error, got %v", output)
+		}
+		if time.Since(start) > 500*time.Millisecond || calls >= len(elements) {
+			t.Errorf("validation did not stop after deadline, %d elements got validated", calls)
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Millisecond)
+		defer cancel()
+		nested := cg.ComplexValidator(galidator.Rules{
+			"Items": cg.R("items").Slice().Children(cg.R().RegisteredCustom("slow")),
+		})
+		output := nested.Validate(ctx, map[string]interface{}{"Items": elements})
+		if !galidator.IsCanceled(output) {
+			t.Errorf("expected a canceled error, got %v", output)
+		}
+	})
+
+	t.Run("not-canceled", func(t *testing.T) {
+		check(t, nil, v.Validate(context.TODO(), elements[:2]))
+	})
+}
+
+func TestRuleTimeout(t *testing.T) {
+	tg := galidator.New().CustomValidators(galidator.Validators{
+		"unique": func(ctx context.Context, input interface{}) bool {
+			select {
+			case <-time.After(time.Second):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		},
+		"fast": func(ctx context.Context, input interface{}) bool {
+			return input == "fast"
+		},
+		"panics": func(ctx context.Context, input interface{}) bool {
+			panic("rule panicked")
+		},
+	})
+	v := tg.Validator(tg.R("username").RegisteredCustom("unique").Timeout("unique", 10*time.Millisecond).SpecificMessages(galidator.Messages{"unique": "$field is taken"}))
+	specific := tg.Validator(tg.R("username").RegisteredCustom("unique").Timeout("unique", 10*time.Millisecond).SpecificMessages(galidator.Messages{"timeout": "$field could not be checked"}))
+	fast := tg.Validator(tg.R("username").RegisteredCustom("fast").Timeout("fast", time.Second).SpecificMessages(galidator.Messages{"fast": "$field is not fast"}))
+	panics := tg.Validator(tg.R("username").RegisteredCustom("panics").Timeout("panics", time.Second))
+
+	start := time.Now()
+	check(t, []string{"username could not be validated in time"}, v.Validate(context.TODO(), "user"))
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("rule did not time out")
+	}
+	check(t, []string{"username could not be checked"}, specific.Validate(context.TODO(), "user"))
+	check(t, nil, fast.Validate(context.TODO(), "fast"))
+	check(t, []string{"username is not fast"}, fast.Validate(context.TODO(), "slow"))
+
+	t.Run("problem", func(t *testing.T) {
+		problem, err := v.Problem(context.TODO(), "user")
+		check(t, true, err == nil)
+		check(t, []galidator.InvalidParam{{Name: "", Reason: "username could not be validated in time", Rule: "timeout"}}, problem.InvalidParams)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		defer deferTestCases(t, true, "rule panicked")
+		panics.Validate(context.TODO(), "user")
+	})
+}
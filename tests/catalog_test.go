@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+func TestCatalog(t *testing.T) {
+	galidator.RegisterCatalog("fa", galidator.Messages{"catalog_even": "$value زوج نیست"})
+	galidator.RegisterCatalog("en-PIRATE", galidator.Messages{"required": "arr, ye must fill it"})
+	even := func(ctx context.Context, input interface{}) bool {
+		return input.(int)%2 == 0
+	}
+
+	scenarios := []scenario{
+		{
+			name:      "default-en",
+			validator: galidator.G().Validator(galidator.G().R().Required()),
+			in:        "",
+			panic:     false,
+			expected:  []string{"required"},
+		},
+		{
+			name:      "fa",
+			validator: galidator.G().Locale("fa").Validator(galidator.G().R().Required()),
+			in:        "",
+			panic:     false,
+			expected:  []string{"الزامی است"},
+		},
+		{
+			name:      "fallback-de-DE",
+			validator: galidator.G().Locale("de_DE").Validator(galidator.G().R().Int()),
+			in:        "1",
+			panic:     false,
+			expected:  []string{"keine ganze Zahl"},
+		},
+		{
+			name:      "fallback-unknown",
+			validator: galidator.G().Locale("xx").Validator(galidator.G().R().Required()),
+			in:        "",
+			panic:     false,
+			expected:  []string{"required"},
+		},
+		{
+			name:      "registered-locale",
+			validator: galidator.G().Locale("en_pirate").Validator(galidator.G().R().Required().Int()),
+			in:        "",
+			panic:     false,
+			expected:  []string{"not an integer value", "arr, ye must fill it"},
+		},
+		{
+			name:      "specific-message-wins",
+			validator: galidator.G().Locale("fa").Validator(galidator.G().R().Required().SpecificMessages(galidator.Messages{"required": "specific"})),
+			in:        "",
+			panic:     false,
+			expected:  []string{"specific"},
+		},
+		{
+			name:      "custom-validator-key",
+			validator: galidator.G().Locale("fa").CustomValidators(galidator.Validators{"catalog_even": even}).Validator(galidator.G().R().Custom(galidator.Validators{"catalog_even": even})),
+			in:        3,
+			panic:     false,
+			expected:  []string{"3 زوج نیست"},
+		},
+		{
+			name: "nested-struct",
+			validator: galidator.G().Locale("es").Validator(struct {
+				Name string `json:"name" g:"required"`
+			}{}),
+			in: struct {
+				Name string `json:"name" g:"required"`
+			}{},
+			panic:    false,
+			expected: map[string]interface{}{"name": []string{"obligatorio"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
+
+func TestLocaleTranslator(t *testing.T) {
+	v := galidator.G().Locale("fr").Validator(galidator.G().R().Required())
+
+	scenarios := []scenario{
+		{
+			name:      "to-ru",
+			validator: v,
+			in:        "",
+			panic:     false,
+			expected:  []string{"обязательное поле"},
+		},
+		{
+			name:      "to-zh",
+			validator: galidator.G().Validator(galidator.G().R().Required()),
+			in:        "",
+			panic:     false,
+			expected:  []string{"必填"},
+		},
+	}
+
+	translators := map[string]galidator.Translator{
+		"to-ru": galidator.LocaleTranslator("ru"),
+		"to-zh": galidator.LocaleTranslator("zh-CN"),
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in, translators[s.name])
+			check(t, s.expected, output)
+		})
+	}
+}
+
+func TestCatalogs(t *testing.T) {
+	for _, locale := range []string{"ar", "de", "en", "es", "fa", "fr", "ru", "zh"} {
+		catalog := galidator.Catalog(locale)
+		if catalog == nil {
+			t.Fatalf("there is no catalog for %s", locale)
+		}
+		for key := range galidator.DefaultMessages() {
+			if catalog[key] == "" {
+				t.Errorf("catalog of %s has no message for %s", locale, key)
+			}
+		}
+	}
+	if catalog := galidator.Catalog("de-AT"); !reflect.DeepEqual(catalog, galidator.Catalog("de")) {
+		t.Errorf("expected catalog of de for de-AT")
+	}
+	for _, locale := range []string{"xx", "xx-YY", ""} {
+		if catalog := galidator.Catalog(locale); catalog != nil {
+			t.Errorf("expected no catalog for %q, got %v", locale, catalog)
+		}
+	}
+}
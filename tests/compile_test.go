@@ -0,0 +1,212 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type compileItem struct {
+	Name  string  `json:"name" g:"required,len_range=2&16" required:"$field is required" len_range:"$field length is invalid"`
+	Price float64 `json:"price" g:"min=0" min:"$field can not be negative"`
+}
+
+type compileOrder struct {
+	EmbeddedPagination
+	ID       int            `json:"id" g:"required,min=1" required:"$field is required"`
+	Email    string         `json:"email" g:"email" email:"$value is not an email"`
+	Tags     []string       `json:"tags" g:"c.len_range=2&8" c.len_range:"$value length is invalid"`
+	Items    []*compileItem `json:"items" g:"required" required:"$field is required"`
+	Shipping *compileItem   `json:"shipping"`
+	Billing  compileItem    `json:"billing"`
+}
+
+func TestCompile(t *testing.T) {
+	types := []struct {
+		name         string
+		newValidator func() galidator.Validator
+		inputs       []interface{}
+	}{
+		{
+			name:         "order",
+			newValidator: func() galidator.Validator { return g.Validator(compileOrder{}) },
+			inputs: []interface{}{
+				compileOrder{EmbeddedPagination: EmbeddedPagination{Page: 1}, ID: 1, Email: "m@g.com", Tags: []string{"tag"}, Items: []*compileItem{{Name: "item"}}, Billing: compileItem{Name: "billing"}},
+				&compileOrder{Email: "invalid", Tags: []string{"t", "tag"}, Items: []*compileItem{{Name: "i", Price: -1}, nil}, Shipping: &compileItem{}},
+				compileOrder{},
+			},
+		},
+		{
+			name:         "embedded",
+			newValidator: func() galidator.Validator { return g.Validator(embeddedRequest{}) },
+			inputs: []interface{}{
+				embeddedRequest{EmbeddedPagination{Page: 0, PerPage: 101}, nil, ""},
+				embeddedRequest{EmbeddedPagination{Page: 1, PerPage: 10}, &EmbeddedAuditFields{}, "name"},
+			},
+		},
+		{
+			name:         "unexported",
+			newValidator: func() galidator.Validator { return g.Validator(unexportedTest{}) },
+			inputs: []interface{}{
+				unexportedTest{b: "b", secret: "s"},
+				unexportedTest{A: "a", C: "c", E: "e", secret: "secret"},
+			},
+		},
+	}
+
+	for _, typ := range types {
+		compiled := typ.newValidator().Compile(typ.inputs...)
+		uncompiled := typ.newValidator()
+		for _, in := range typ.inputs {
+			scenarios := []struct {
+				scenario
+				partial bool
+			}{
+				{
+					scenario: scenario{
+						name:      typ.name,
+						validator: compiled,
+						in:        in,
+						panic:     false,
+						expected:  normalizeErrors(uncompiled.Validate(context.TODO(), in)),
+					},
+					partial: false,
+				},
+				{
+					scenario: scenario{
+						name:      typ.name + "-partial",
+						validator: compiled,
+						in:        in,
+						panic:     false,
+						expected:  normalizeErrors(uncompiled.ValidatePartial(context.TODO(), in)),
+					},
+					partial: true,
+				},
+			}
+
+			for _, s := range scenarios {
+				t.Run(s.name, func(t *testing.T) {
+					defer deferTestCases(t, s.panic, s.expected)
+
+					var output interface{}
+					if s.partial {
+						output = s.validator.ValidatePartial(context.TODO(), s.in)
+					} else {
+						output = s.validator.Validate(context.TODO(), s.in)
+					}
+					check(t, s.expected, normalizeErrors(output))
+				})
+			}
+		}
+	}
+}
+
+type planFields struct {
+	*EmbeddedAuditFields
+	Any     interface{}
+	Note    string
+	Details map[string]interface{}
+}
+
+func TestPlan(t *testing.T) {
+	validator := g.ComplexValidator(galidator.Rules{
+		"Any":     g.R("any").Complex(galidator.Rules{"Name": g.R("name").Required().SpecificMessages(galidator.Messages{"required": "$field is required"})}),
+		"Note":    g.R("note").WhenExistOne("CreatedBy").SpecificMessages(galidator.Messages{"when_exist_one": "$field is required with created_by"}),
+		"Details": g.R("details").Complex(galidator.Rules{"id": g.R("id").Required().SpecificMessages(galidator.Messages{"required": "$field is required"})}),
+	})
+	scenarios := []scenario{
+		{
+			name:      "interface-struct",
+			validator: validator,
+			in:        planFields{EmbeddedAuditFields: &EmbeddedAuditFields{}, Any: compileItem{Price: 1}},
+			panic:     false,
+			expected:  map[string]interface{}{"any": map[string]interface{}{"name": []string{"name is required"}}},
+		},
+		{
+			name:      "interface-string",
+			validator: validator,
+			in:        planFields{EmbeddedAuditFields: &EmbeddedAuditFields{}, Any: "any", Details: map[string]interface{}{"id": 1}},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "requires",
+			validator: validator,
+			in:        planFields{EmbeddedAuditFields: &EmbeddedAuditFields{CreatedBy: "me"}, Details: map[string]interface{}{"id": nil}},
+			panic:     false,
+			expected:  map[string]interface{}{"note": []string{"note is required with created_by"}, "details": map[string]interface{}{"id": []string{"id is required"}}},
+		},
+		{
+			name:      "requires-nil-embedded",
+			validator: validator,
+			in:        planFields{},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "requires-nil-embedded-other-errors",
+			validator: validator,
+			in:        planFields{Details: map[string]interface{}{"id": nil}},
+			panic:     false,
+			expected:  map[string]interface{}{"details": map[string]interface{}{"id": []string{"id is required"}}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
+
+func newBenchmarkOrder() compileOrder {
+	return compileOrder{
+		EmbeddedPagination: EmbeddedPagination{Page: 1, PerPage: 10},
+		ID:                 1,
+		Email:              "m@g.com",
+		Tags:               []string{"tag", "second"},
+		Items:              []*compileItem{{Name: "first", Price: 10}, {Name: "second", Price: 20}},
+		Shipping:           &compileItem{Name: "shipping", Price: 5},
+		Billing:            compileItem{Name: "billing"},
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	validator := g.Validator(compileOrder{})
+	in := newBenchmarkOrder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		validator.Validate(context.TODO(), in)
+	}
+}
+
+func BenchmarkValidateCompiled(b *testing.B) {
+	in := newBenchmarkOrder()
+
+	// A new validator on every iteration, so its plan gets resolved in Validate
+	b.Run("cold", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			validator := g.Validator(compileOrder{})
+			b.StartTimer()
+			validator.Validate(context.TODO(), in)
+		}
+	})
+
+	b.Run("warm", func(b *testing.B) {
+		validator := g.Validator(compileOrder{}).Compile(in)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			validator.Validate(context.TODO(), in)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type concurrencyUser struct {
+	Name  string `json:"name" g:"required,len_range=3&10"`
+	Email string `json:"email" g:"email"`
+}
+
+func TestConcurrency(t *testing.T) {
+	cg := galidator.New().CustomMessages(galidator.Messages{"len_range": "$field has a wrong length"})
+	cg.RegisterType(concurrencyUser{})
+	shared := cg.ComplexValidator(galidator.Rules{
+		"Name":  cg.R("name").Required().LenRange(3, 10),
+		"Email": cg.R("email").Email(),
+		"Any":   cg.R("any").Dynamic(),
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			message := fmt.Sprintf("invalid email %d", i)
+			v := cg.Validator(concurrencyUser{}, galidator.Messages{"email": message})
+			if i%5 == 0 {
+				cg.RegisterType(&concurrencyUser{})
+			}
+			for j := 0; j < 20; j++ {
+				check(t, map[string][]string{"email": {message}, "name": {"name has a wrong length"}}, v.Validate(context.TODO(), concurrencyUser{Name: "ab", Email: "invalid"}))
+				check(t, map[string]interface{}{"any": map[string][]string{"name": {"name has a wrong length"}}}, shared.Validate(context.TODO(), map[string]interface{}{"Name": "abc", "Email": "m@g.com", "Any": concurrencyUser{Name: "ab"}}))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if messages := cg.GetMessages(); len(messages) != 1 {
+		t.Errorf("messages passed to validators leaked into generator: %v", messages)
+	}
+}
+
+func TestClone(t *testing.T) {
+	r := g.R("name").String().SpecificMessages(galidator.Messages{"len": "$field is short"})
+	v := g.Validator(r)
+	clone := r.Clone().Len(5)
+	r.Required()
+
+	check(t, nil, v.Validate(context.TODO(), ""))
+	check(t, nil, g.Validator(r.Clone().Optional()).Validate(context.TODO(), "abc"))
+	check(t, []string{"name is short"}, g.Validator(clone).Validate(context.TODO(), "abc"))
+
+	complexValidator := g.ComplexValidator(galidator.Rules{
+		"Name": g.R("name").Required(),
+	}).Compile(concurrencyUser{})
+	validatorClone := complexValidator.Clone()
+	validatorClone.GetStructRule("Name").Len(5)
+	check(t, true, complexValidator.GetRule() == nil)
+
+	check(t, nil, complexValidator.Validate(context.TODO(), concurrencyUser{Name: "abc"}))
+	check(t, nil, validatorClone.Validate(context.TODO(), concurrencyUser{Name: "abc"}))
+	check(t, map[string][]string{"name": {"name's length must be equal to 5"}}, g.ComplexValidator(galidator.Rules{"Name": validatorClone.GetStructRule("Name").Len(5)}).Validate(context.TODO(), concurrencyUser{Name: "abc"}))
+}
@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+var divisibleBy = galidator.CustomRule{
+	Key: "divisible_by",
+	Func: func(ctx context.Context, input interface{}, params ...string) bool {
+		divisor, err := strconv.Atoi(params[0])
+		number, ok := input.(int)
+		return err == nil && ok && number%divisor == 0
+	},
+	Params:       []string{"divisor"},
+	Message:      "$field must be divisible by $divisor",
+	Translations: galidator.Messages{"fa": "$field باید بر $divisor بخش پذیر باشد"},
+}
+
+var positive = galidator.CustomRule{
+	Key: "positive_number",
+	Tag: "positive",
+	Func: func(ctx context.Context, input interface{}, params ...string) bool {
+		number, ok := input.(int)
+		return ok && number > 0
+	},
+	Message: "$field must be positive",
+}
+
+type customRuleTagged struct {
+	Count int `json:"count" g:"divisible_by=3,positive"`
+	Total int `json:"total" g:"positive" positive_number:"$field can not be $value"`
+}
+
+func TestCustomRules(t *testing.T) {
+	rulesGenerator := galidator.G().RegisterRules(divisibleBy, positive)
+	faGenerator := galidator.G().RegisterRules(divisibleBy).Locale("fa-IR")
+	minGenerator := galidator.G().RegisterRules(galidator.CustomRule{Key: "min", Func: positive.Func, Message: "replaced"})
+
+	scenarios := []scenario{
+		{
+			name:      "builder-pass",
+			validator: rulesGenerator.Validator(rulesGenerator.R("count").Rule("divisible_by", "3")),
+			in:        6,
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "builder-fail",
+			validator: rulesGenerator.Validator(rulesGenerator.R("count").Rule("divisible_by", "4")),
+			in:        6,
+			panic:     false,
+			expected:  []string{"count must be divisible by 4"},
+		},
+		{
+			name:      "tag-name",
+			validator: rulesGenerator.Validator(rulesGenerator.R("count").Rule("positive")),
+			in:        -1,
+			panic:     false,
+			expected:  []string{"count must be positive"},
+		},
+		{
+			name:      "tags",
+			validator: rulesGenerator.Validator(customRuleTagged{}),
+			in:        customRuleTagged{Count: -2, Total: 0},
+			panic:     false,
+			expected:  map[string]interface{}{"count": []string{"count must be divisible by 3", "count must be positive"}, "total": []string{"total can not be 0"}},
+		},
+		{
+			name:      "translation",
+			validator: faGenerator.Validator(faGenerator.R("count").Rule("divisible_by", "3")),
+			in:        4,
+			panic:     false,
+			expected:  []string{"count باید بر 3 بخش پذیر باشد"},
+		},
+		{
+			name:      "other-generator",
+			validator: galidator.G().MissingMessages(galidator.IgnoreMissingMessages).Validator(rulesGenerator.R("count").Rule("divisible_by", "3")),
+			in:        4,
+			panic:     false,
+			expected:  []string{"error happened but no error message exists on 'divisible_by' rule key"},
+		},
+		{
+			name:      "built-in-message",
+			validator: minGenerator.Validator(minGenerator.R("count").Min(5)),
+			in:        4,
+			panic:     false,
+			expected:  []string{"count's length must be higher equal to 5"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+
+	panics := map[string]func(){
+		"wrong-parameters": func() { rulesGenerator.R().Rule("divisible_by") },
+		"not-registered":   func() { galidator.G().R().Rule("divisible_by", "3") },
+		"duplicate":        func() { rulesGenerator.R().Rule("positive").Rule("positive_number") },
+		"empty-key":        func() { galidator.G().RegisterRules(galidator.CustomRule{Func: positive.Func}) },
+	}
+	for name, f := range panics {
+		t.Run(name, func(t *testing.T) {
+			defer deferTestCases(t, true, nil)
+			f()
+		})
+	}
+}
+
+func TestMissingMessages(t *testing.T) {
+	unknown := galidator.Validators{"unknown_rule": func(ctx context.Context, input interface{}) bool { return true }}
+	strict := galidator.G().MissingMessages(galidator.FailOnMissingMessages)
+
+	if _, err := strict.Build(strict.R().Custom(unknown)); err == nil || !strings.Contains(err.Error(), "no error message exists on 'unknown_rule' rule key") {
+		t.Errorf("expected a build error, err = %v", err)
+	}
+	if _, err := strict.Build(strict.R().Custom(unknown), galidator.Messages{"unknown_rule": "unknown"}); err != nil {
+		t.Errorf("expected no error, err = %v", err)
+	}
+	if _, err := strict.Build(strict.R().Custom(unknown).SpecificMessages(galidator.Messages{"unknown_rule": "unknown"})); err != nil {
+		t.Errorf("expected no error, err = %v", err)
+	}
+	if _, err := strict.Build(strict.R().When(galidator.FieldEquals("a", 1)).Then(strict.R().Custom(unknown))); err == nil {
+		t.Errorf("expected a build error for rules of conditions")
+	}
+	if _, err := strict.RegisterRules(divisibleBy, positive).Build(customRuleTagged{}); err != nil {
+		t.Errorf("expected no error for registered rules, err = %v", err)
+	}
+
+	warnings := []string{}
+	warn := func(err error) { warnings = append(warnings, err.Error()) }
+	warning := galidator.G().MissingMessages(galidator.WarnMissingMessages, warn)
+	if _, err := warning.Build(warning.R().Custom(unknown)); err != nil {
+		t.Errorf("expected no error, err = %v", err)
+	}
+	check(t, []string{"no error message exists on 'unknown_rule' rule key"}, warnings)
+
+	output := &bytes.Buffer{}
+	log.SetOutput(output)
+	defer log.SetOutput(os.Stderr)
+	if _, err := galidator.G().Build(galidator.G().R().Custom(unknown)); err != nil {
+		t.Errorf("expected no error, err = %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("expected no warning by default, output = %s", output.String())
+	}
+
+	t.Run("warn-without-function", func(t *testing.T) {
+		defer deferTestCases(t, true, "WarnMissingMessages mode needs a function to pass warnings to")
+		galidator.G().MissingMessages(galidator.WarnMissingMessages)
+	})
+}
@@ -3,6 +3,8 @@ package tests
 import (
 	"context"
 	"testing"
+
+	"github.com/golodash/galidator/v2"
 )
 
 func TestCustomValidatorsFromGenerators(t *testing.T) {
@@ -31,4 +33,14 @@ func TestCustomValidatorsFromGenerators(t *testing.T) {
 			check(t, s.expected, output)
 		})
 	}
+
+	t.Run("set-later", func(t *testing.T) {
+		laterG := galidator.New()
+		r := laterG.R()
+		laterG.CustomValidators(galidator.Validators{"later": func(ctx context.Context, input interface{}) bool {
+			return false
+		}})
+		v := laterG.Validator(r.RegisteredCustom("later").SpecificMessages(galidator.Messages{"later": "later error"}))
+		check(t, []string{"later error"}, v.Validate(context.TODO(), "some text"))
+	})
 }
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type dynamicEvent interface {
+	EventName() string
+}
+
+type dynamicUserCreated struct {
+	Email string `json:"email" g:"email" email:"$value is not an email"`
+}
+
+func (dynamicUserCreated) EventName() string { return "user_created" }
+
+type dynamicUserDeleted struct {
+	ID int
+}
+
+func (dynamicUserDeleted) EventName() string { return "user_deleted" }
+
+type dynamicUnknown struct {
+	Note string
+}
+
+func (dynamicUnknown) EventName() string { return "unknown" }
+
+type dynamicPing string
+
+func (dynamicPing) EventName() string { return "ping" }
+
+type dynamicEnvelope struct {
+	Payload  dynamicEvent   `json:"payload"`
+	Payloads []dynamicEvent `json:"payloads"`
+	Any      interface{}    `json:"any"`
+}
+
+func TestDynamic(t *testing.T) {
+	dg := galidator.New()
+	dg.RegisterType(&dynamicUserCreated{}).
+		RegisterType(dynamicPing("")).
+		RegisterType(dynamicUserDeleted{}, dg.ComplexValidator(galidator.Rules{
+			"ID": dg.R("id").Min(1).SpecificMessages(galidator.Messages{"min": "$field must be positive"}),
+		})).
+		DefaultTypeValidator(dg.ComplexValidator(galidator.Rules{
+			"Note": dg.R("note").String().Len(3).SpecificMessages(galidator.Messages{"len": "$field must be 3 characters long"}),
+		}))
+	v := dg.Validator(dynamicEnvelope{})
+
+	scenarios := []scenario{
+		{
+			name:      "pass",
+			validator: v,
+			in: dynamicEnvelope{
+				Payload:  &dynamicUserCreated{Email: "m@g.com"},
+				Payloads: []dynamicEvent{dynamicUserDeleted{ID: 1}, dynamicUnknown{Note: "abc"}, dynamicPing("ping")},
+				Any:      dynamicUserDeleted{ID: 2},
+			},
+			panic:    false,
+			expected: nil,
+		},
+		{
+			name:      "fail-field",
+			validator: v,
+			in: dynamicEnvelope{
+				Payload: &dynamicUserCreated{Email: "invalid"},
+				Any:     &dynamicUserDeleted{ID: -1},
+			},
+			panic: false,
+			expected: map[string]interface{}{
+				"payload": map[string][]string{"email": {"invalid is not an email"}},
+				"any":     map[string][]string{"id": {"id must be positive"}},
+			},
+		},
+		{
+			name:      "fail-children",
+			validator: v,
+			in: dynamicEnvelope{
+				Payloads: []dynamicEvent{dynamicUserCreated{Email: "m@g.com"}, dynamicUnknown{Note: "abcd"}, nil},
+			},
+			panic:    false,
+			expected: map[string]interface{}{"payloads": map[string]interface{}{"1": map[string][]string{"note": {"note must be 3 characters long"}}}},
+		},
+		{
+			name:      "fail-ruleSet",
+			validator: dg.Validator(dg.R().Dynamic()),
+			in:        dynamicUserDeleted{ID: -5},
+			panic:     false,
+			expected:  map[string][]string{"id": {"id must be positive"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"testing"
+)
+
+type EmbeddedPagination struct {
+	Page    int `json:"page" g:"min=1" min:"$field must be at least $min"`
+	PerPage int `json:"per_page" g:"max=100" max:"$field must be at most $max"`
+}
+
+type EmbeddedAuditFields struct {
+	CreatedBy string `json:"created_by" g:"required" required:"$field is required"`
+}
+
+type embeddedRequest struct {
+	EmbeddedPagination
+	*EmbeddedAuditFields
+	Name string `json:"name" g:"required" required:"$field is required"`
+}
+
+type embeddedShadowed struct {
+	EmbeddedPagination
+	Page string `json:"page" g:"len=2" len:"$field is shadowed"`
+}
+
+type embeddedNestedByJson struct {
+	EmbeddedPagination `json:"pagination"`
+}
+
+type embeddedNestedByTag struct {
+	EmbeddedPagination `g:"nested"`
+}
+
+func TestEmbedded(t *testing.T) {
+	scenarios := []scenario{
+		{
+			name:      "pass",
+			validator: g.Validator(embeddedRequest{}),
+			in:        embeddedRequest{EmbeddedPagination{Page: 1, PerPage: 10}, &EmbeddedAuditFields{CreatedBy: "admin"}, "name"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-promoted",
+			validator: g.Validator(embeddedRequest{}),
+			in:        embeddedRequest{EmbeddedPagination{Page: 1, PerPage: 101}, &EmbeddedAuditFields{CreatedBy: "admin"}, "name"},
+			panic:     false,
+			expected:  map[string][]string{"per_page": {"per_page must be at most 100"}},
+		},
+		{
+			name:      "fail-nil-embedded-pointer",
+			validator: g.Validator(embeddedRequest{}),
+			in:        embeddedRequest{EmbeddedPagination{Page: 1}, nil, "name"},
+			panic:     false,
+			expected:  map[string][]string{"created_by": {"created_by is required"}},
+		},
+		{
+			name:      "pass-shadowed",
+			validator: g.Validator(embeddedShadowed{}),
+			in:        embeddedShadowed{EmbeddedPagination{Page: -1}, "ab"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-shadowed",
+			validator: g.Validator(embeddedShadowed{}),
+			in:        embeddedShadowed{EmbeddedPagination{Page: 1}, "abc"},
+			panic:     false,
+			expected:  map[string][]string{"page": {"page is shadowed"}},
+		},
+		{
+			name:      "fail-nested-by-json",
+			validator: g.Validator(embeddedNestedByJson{}),
+			in:        embeddedNestedByJson{EmbeddedPagination{Page: 1, PerPage: 101}},
+			panic:     false,
+			expected:  map[string]map[string][]string{"pagination": {"per_page": {"per_page must be at most 100"}}},
+		},
+		{
+			name:      "fail-nested-by-tag",
+			validator: g.Validator(embeddedNestedByTag{}),
+			in:        embeddedNestedByTag{EmbeddedPagination{Page: 1, PerPage: 101}},
+			panic:     false,
+			expected:  map[string]map[string][]string{"EmbeddedPagination": {"per_page": {"per_page must be at most 100"}}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
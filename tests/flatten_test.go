@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/golodash/galidator/v2"
+)
+
+type flatItem struct {
+	Zip string `json:"zip" g:"required,len=5" binding:"required,len=5" required:"$field is required" len:"$field must have 5 characters"`
+}
+
+type flatOrder struct {
+	Name  string     `json:"name" g:"required" binding:"required" required:"$field is required"`
+	Items []flatItem `json:"items" binding:"dive"`
+}
+
+func TestFlatten(t *testing.T) {
+	v := g.Validator(flatOrder{})
+	items := []flatItem{{Zip: "1"}, {Zip: "12345"}, {Zip: "123"}}
+	for i := 0; i < 9; i++ {
+		items = append(items, flatItem{Zip: "12345"})
+	}
+	items = append(items, flatItem{})
+	in := flatOrder{Items: items}
+
+	scenarios := []struct {
+		name     string
+		errors   interface{}
+		format   galidator.PathFormat
+		expected interface{}
+	}{
+		{
+			name:   "json-pointer",
+			errors: v.Validate(context.TODO(), in),
+			format: galidator.JSONPointer,
+			expected: map[string][]string{
+				"/items/0/zip":  {"zip must have 5 characters"},
+				"/items/2/zip":  {"zip must have 5 characters"},
+				"/items/12/zip": {"zip must have 5 characters", "zip is required"},
+				"/name":         {"name is required"},
+			},
+		},
+		{
+			name:   "dotted-path",
+			errors: v.Validate(context.TODO(), in),
+			format: galidator.DottedPath,
+			expected: map[string][]string{
+				"items.0.zip":  {"zip must have 5 characters"},
+				"items.2.zip":  {"zip must have 5 characters"},
+				"items.12.zip": {"zip must have 5 characters", "zip is required"},
+				"name":         {"name is required"},
+			},
+		},
+		{
+			name:   "decrypt-errors",
+			errors: v.DecryptErrors(binding.Validator.ValidateStruct(in)),
+			format: galidator.JSONPointer,
+			expected: map[string][]string{
+				"/items/0/zip":  {"zip must have 5 characters"},
+				"/items/2/zip":  {"zip must have 5 characters"},
+				"/items/12/zip": {"zip is required"},
+				"/name":         {"name is required"},
+			},
+		},
+		{
+			name:     "escape",
+			errors:   map[string]interface{}{"a/b": map[string]interface{}{"~c": []string{"bad"}}},
+			format:   galidator.JSONPointer,
+			expected: map[string][]string{"/a~1b/~0c": {"bad"}},
+		},
+		{
+			name:     "root",
+			errors:   g.Validator(g.R().Required().SpecificMessages(galidator.Messages{"required": "required"})).Validate(context.TODO(), ""),
+			format:   galidator.DottedPath,
+			expected: map[string][]string{"": {"required"}},
+		},
+		{
+			name:     "valid",
+			errors:   v.Validate(context.TODO(), flatOrder{Name: "a"}),
+			format:   galidator.JSONPointer,
+			expected: map[string][]string(nil),
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, false, s.expected)
+
+			check(t, s.expected, galidator.Flatten(s.errors, s.format))
+		})
+	}
+
+	t.Run("list", func(t *testing.T) {
+		paths := []string{}
+		messages := [][]string{}
+		for _, field := range galidator.FlattenList(v.Validate(context.TODO(), in), galidator.JSONPointer) {
+			paths = append(paths, field.Path)
+			messages = append(messages, field.Messages)
+		}
+		check(t, []string{"/items/0/zip", "/items/2/zip", "/items/12/zip", "/name"}, paths)
+		check(t, [][]string{{"zip must have 5 characters"}, {"zip must have 5 characters"}, {"zip must have 5 characters", "zip is required"}, {"name is required"}}, messages)
+	})
+}
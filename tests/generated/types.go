@@ -0,0 +1,68 @@
+// Package generated holds structs which galidator-gen generates Validate methods for, used to test the generated code
+package generated
+
+import (
+	"context"
+
+	"github.com/golodash/galidator/v2"
+)
+
+//go:generate go run ../../cmd/galidator-gen -generator G -output types_galidator.go
+
+// Generator of custom validators and messages
+var G = galidator.New().CustomValidators(galidator.Validators{
+	"even": func(ctx context.Context, input interface{}) bool {
+		number, ok := input.(int)
+		return ok && number%2 == 0
+	},
+}).CustomMessages(galidator.Messages{
+	"even":  "$field must be even",
+	"email": "$value is not an email",
+})
+
+type Address struct {
+	City    string  `json:"city" g:"required" required:"$field is required" label:"City name"`
+	Zip     *string `json:"zip" g:"len=5"`
+	Country string  `json:"country" g:"choices=US&IR&DE"`
+}
+
+type Item struct {
+	Name     string  `json:"name" g:"required,len_range=2&16"`
+	Price    float64 `json:"price" g:"min=0.5" min:"$field can not be lower than $min"`
+	Quantity int     `json:"quantity" g:"even"`
+}
+
+type User struct {
+	Username  string     `json:"username" g:"required,len_range=3&16" len_range:"$field length is invalid"`
+	Email     string     `json:"email" g:"email"`
+	Phone     *string    `json:"phone" g:"phone,sensitive"`
+	Password  string     `json:"password" g:"password"`
+	Age       *int       `json:"age" g:"non_nil,max=150"`
+	Score     float64    `json:"score" g:"max=10"`
+	Active    bool       `json:"active" g:"required"`
+	Tags      []string   `json:"tags" g:"max=3,c.len_range=2&8" c.len_range:"$value length is invalid"`
+	Numbers   []*int     `json:"numbers" g:"c.required,c.min=1"`
+	Contact   string     `json:"contact" g:"or=email|phone"`
+	Code      string     `json:"code" g:"xor=len=2|len=4" xor:"$field must be 2 or 4 characters long"`
+	Nickname  string     `json:"nickname" g:"when_exist_one=Username"`
+	Reason    string     `json:"reason" g:"when_not_exist_all=Email&Phone"`
+	Home      Address    `json:"home"`
+	Work      *Address   `json:"work" g:"required"`
+	Items     []Item     `json:"items" g:"non_empty"`
+	Favorites []*Item    `json:"favorites"`
+	secret    string     `g:"allow_unexported,min=3"`
+	Ignored   chan int   `json:"ignored"`
+	Friends   []*Address `json:"friends"`
+	Bio       string     `json:"bio" g:"max=1000" max:"{max, plural, one {# character} other {# characters}} at most, $$value is not shown"`
+	Phones    []string   `json:"phones" g:"sensitive,c.phone"`
+}
+
+// Returns a copy of secret field
+func (u *User) Secret() string {
+	return u.secret
+}
+
+// Sets secret field
+func (u *User) SetSecret(secret string) {
+	u.secret = secret
+}
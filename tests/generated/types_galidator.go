@@ -0,0 +1,526 @@
+// Code generated by galidator-gen. DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strconv"
+
+	"github.com/dlclark/regexp2"
+	"github.com/golodash/galidator/v2"
+	"github.com/nyaruka/phonenumbers"
+)
+
+var (
+	galidatorRegex0 = regexp2.MustCompile("^(?=.*[a-z])(?=.*[A-Z])(?=.*\\d)(?=.*[ !\"#$%&'()*+,-.\\/:;<=>?@[\\]^_`{|}~])[A-Za-z\\d !\"#$%&'()*+,-.\\/:;<=>?@[\\]^_`{|}~]{8,}$", regexp2.None)
+	galidatorRegex1 = regexp2.MustCompile(`^\+\d+$`, regexp2.None)
+)
+
+// Validate validates x like a galidator validator which is generated from Address, returns nil if no errors found
+func (x *Address) Validate(ctx context.Context) map[string]interface{} {
+	output := map[string]interface{}{}
+	// City
+	{
+		value := x.City
+		var current interface{} = value
+		errors := []string{}
+		if !(value != "") {
+			errors = append(errors, galidatorMessage(ctx, "required", "$field is required", "city", "City name", current))
+		}
+		if len(errors) != 0 {
+			output["city"] = errors
+		}
+	}
+	// Zip
+	{
+		value := x.Zip
+		if !(value == nil) {
+			var current interface{}
+			if value != nil {
+				current = *value
+			}
+			errors := []string{}
+			if !(value != nil && len(*value) == 5) {
+				errors = append(errors, galidatorMessage(ctx, "len", "", "zip", "", current, "length", "5"))
+			}
+			if len(errors) != 0 {
+				output["zip"] = errors
+			}
+		}
+	}
+	// Country
+	{
+		value := x.Country
+		if !(value == "") {
+			var current interface{} = value
+			errors := []string{}
+			if !(value == "US" || value == "IR" || value == "DE") {
+				errors = append(errors, galidatorMessage(ctx, "choices", "", "country", "", current, "choices", "[US, IR, DE]"))
+			}
+			if len(errors) != 0 {
+				output["country"] = errors
+			}
+		}
+	}
+	if len(output) == 0 {
+		return nil
+	}
+	return output
+}
+
+// Validate validates x like a galidator validator which is generated from Item, returns nil if no errors found
+func (x *Item) Validate(ctx context.Context) map[string]interface{} {
+	output := map[string]interface{}{}
+	// Name
+	{
+		value := x.Name
+		var current interface{} = value
+		errors := []string{}
+		if !(value != "") {
+			errors = append(errors, galidatorMessage(ctx, "required", "", "name", "", current))
+		}
+		if !(len(value) >= 2 && len(value) <= 16) {
+			errors = append(errors, galidatorMessage(ctx, "len_range", "", "name", "", current, "from", "2", "to", "16"))
+		}
+		if len(errors) != 0 {
+			output["name"] = errors
+		}
+	}
+	// Price
+	{
+		value := x.Price
+		var current interface{} = value
+		errors := []string{}
+		if !(float64(value) >= 0.5) {
+			errors = append(errors, galidatorMessage(ctx, "min", "$field can not be lower than $min", "price", "", current, "min", "0.5"))
+		}
+		if len(errors) != 0 {
+			output["price"] = errors
+		}
+	}
+	// Quantity
+	{
+		value := x.Quantity
+		var current interface{} = value
+		errors := []string{}
+		if !(galidatorCustom("even")(ctx, current)) {
+			errors = append(errors, galidatorMessage(ctx, "even", "", "quantity", "", current))
+		}
+		if len(errors) != 0 {
+			output["quantity"] = errors
+		}
+	}
+	if len(output) == 0 {
+		return nil
+	}
+	return output
+}
+
+// Validate validates x like a galidator validator which is generated from User, returns nil if no errors found
+func (x *User) Validate(ctx context.Context) map[string]interface{} {
+	output := map[string]interface{}{}
+	// Username
+	{
+		value := x.Username
+		var current interface{} = value
+		errors := []string{}
+		if !(value != "") {
+			errors = append(errors, galidatorMessage(ctx, "required", "", "username", "", current))
+		}
+		if !(len(value) >= 3 && len(value) <= 16) {
+			errors = append(errors, galidatorMessage(ctx, "len_range", "$field length is invalid", "username", "", current, "from", "3", "to", "16"))
+		}
+		if len(errors) != 0 {
+			output["username"] = errors
+		}
+	}
+	// Email
+	{
+		value := x.Email
+		if !(value == "") {
+			var current interface{} = value
+			errors := []string{}
+			if !(galidatorEmail(value)) {
+				errors = append(errors, galidatorMessage(ctx, "email", "", "email", "", current))
+			}
+			if len(errors) != 0 {
+				output["email"] = errors
+			}
+		}
+	}
+	// Phone
+	{
+		value := x.Phone
+		if !(value == nil) {
+			errors := []string{}
+			if !(value != nil && galidatorPhone(*value)) {
+				errors = append(errors, galidatorMessage(ctx, "phone", "", "phone", "", "[REDACTED]"))
+			}
+			if len(errors) != 0 {
+				output["phone"] = errors
+			}
+		}
+	}
+	// Password
+	{
+		value := x.Password
+		if !(value == "") {
+			var current interface{} = value
+			errors := []string{}
+			if !(galidatorMatch(galidatorRegex0, value)) {
+				errors = append(errors, galidatorMessage(ctx, "password", "", "password", "", current))
+			}
+			if len(errors) != 0 {
+				output["password"] = errors
+			}
+		}
+	}
+	// Age
+	{
+		value := x.Age
+		var current interface{}
+		if value != nil {
+			current = *value
+		}
+		errors := []string{}
+		if !(value != nil) {
+			errors = append(errors, galidatorMessage(ctx, "non_nil", "", "age", "", current))
+		}
+		if !(value != nil && float64(*value) <= 150) {
+			errors = append(errors, galidatorMessage(ctx, "max", "", "age", "", current, "max", "150"))
+		}
+		if len(errors) != 0 {
+			output["age"] = errors
+		}
+	}
+	// Score
+	{
+		value := x.Score
+		var current interface{} = value
+		errors := []string{}
+		if !(float64(value) <= 10) {
+			errors = append(errors, galidatorMessage(ctx, "max", "", "score", "", current, "max", "10"))
+		}
+		if len(errors) != 0 {
+			output["score"] = errors
+		}
+	}
+	// Active
+	{
+		value := x.Active
+		var current interface{} = value
+		errors := []string{}
+		if !(value) {
+			errors = append(errors, galidatorMessage(ctx, "required", "", "active", "", current))
+		}
+		if len(errors) != 0 {
+			output["active"] = errors
+		}
+	}
+	// Tags
+	{
+		value := x.Tags
+		if !(len(value) == 0) {
+			var current interface{} = value
+			errors := []string{}
+			if !(len(value) <= 3) {
+				errors = append(errors, galidatorMessage(ctx, "max", "", "tags", "", current, "max", "3"))
+			}
+			if len(errors) != 0 {
+				output["tags"] = errors
+			} else {
+				children := map[string]interface{}{}
+				for i := range value {
+					element := value[i]
+					if !(element == "") {
+						var current interface{} = element
+						errors := []string{}
+						if !(len(element) >= 2 && len(element) <= 8) {
+							errors = append(errors, galidatorMessage(ctx, "len_range", "$value length is invalid", "", "", current, "from", "2", "to", "8"))
+						}
+						if len(errors) != 0 {
+							children[strconv.Itoa(i)] = errors
+						}
+					}
+				}
+				if len(children) != 0 {
+					output["tags"] = children
+				}
+			}
+		}
+	}
+	// Numbers
+	{
+		value := x.Numbers
+		if !(len(value) == 0) {
+			children := map[string]interface{}{}
+			for i := range value {
+				element := value[i]
+				var current interface{}
+				if element != nil {
+					current = *element
+				}
+				errors := []string{}
+				if !(element != nil) {
+					errors = append(errors, galidatorMessage(ctx, "type", "", "", "", current, "type", "int"))
+				}
+				if !(element != nil) {
+					errors = append(errors, galidatorMessage(ctx, "required", "", "", "", current))
+				}
+				if !(element != nil && float64(*element) >= 1) {
+					errors = append(errors, galidatorMessage(ctx, "min", "", "", "", current, "min", "1"))
+				}
+				if len(errors) != 0 {
+					children[strconv.Itoa(i)] = errors
+				}
+			}
+			if len(children) != 0 {
+				output["numbers"] = children
+			}
+		}
+	}
+	// Contact
+	{
+		value := x.Contact
+		if !(value == "") {
+			var current interface{} = value
+			errors := []string{}
+			if !((galidatorEmail(value)) || (galidatorPhone(value))) {
+				errors = append(errors, galidatorMessage(ctx, "or", "", "contact", "", current))
+			}
+			if len(errors) != 0 {
+				output["contact"] = errors
+			}
+		}
+	}
+	// Code
+	{
+		value := x.Code
+		if !(value == "") {
+			var current interface{} = value
+			errors := []string{}
+			if !((len(value) == 4) != ((len(value) == 2) != (false))) {
+				errors = append(errors, galidatorMessage(ctx, "xor", "$field must be 2 or 4 characters long", "code", "", current))
+			}
+			if len(errors) != 0 {
+				output["code"] = errors
+			}
+		}
+	}
+	// Nickname
+	{
+		value := x.Nickname
+		if !(!(value == "" && (!(x.Username == ""))) && value == "") {
+			var current interface{} = value
+			errors := []string{}
+			if !(value != "") {
+				errors = append(errors, galidatorMessage(ctx, "when_exist_one", "", "nickname", "", current, "choices", "[Username]"))
+			}
+			if len(errors) != 0 {
+				output["nickname"] = errors
+			}
+		}
+	}
+	// Reason
+	{
+		value := x.Reason
+		if !(!((x.Email == "" && x.Phone == nil) && value == "") && value == "") {
+			var current interface{} = value
+			errors := []string{}
+			if !(value != "") {
+				errors = append(errors, galidatorMessage(ctx, "when_not_exist_all", "", "reason", "", current, "choices", "[Email, Phone]"))
+			}
+			if len(errors) != 0 {
+				output["reason"] = errors
+			}
+		}
+	}
+	// Home
+	{
+		value := x.Home
+		if !(value.galidatorIsZero()) {
+			if errors := value.Validate(ctx); len(errors) != 0 {
+				output["home"] = errors
+			}
+		}
+	}
+	// Work
+	{
+		value := x.Work
+		var current interface{}
+		if value != nil {
+			current = *value
+		}
+		errors := []string{}
+		if !(value != nil && !value.galidatorIsZero()) {
+			errors = append(errors, galidatorMessage(ctx, "required", "", "work", "", current))
+		}
+		if len(errors) != 0 {
+			output["work"] = errors
+		} else {
+			if value != nil {
+				if errors := value.Validate(ctx); len(errors) != 0 {
+					output["work"] = errors
+				}
+			}
+		}
+	}
+	// Items
+	{
+		value := x.Items
+		var current interface{} = value
+		errors := []string{}
+		if !(len(value) != 0) {
+			errors = append(errors, galidatorMessage(ctx, "non_empty", "", "items", "", current))
+		}
+		if len(errors) != 0 {
+			output["items"] = errors
+		} else {
+			children := map[string]interface{}{}
+			for i := range value {
+				element := &value[i]
+				if errors := element.Validate(ctx); len(errors) != 0 {
+					children[strconv.Itoa(i)] = errors
+				}
+			}
+			if len(children) != 0 {
+				output["items"] = children
+			}
+		}
+	}
+	// Favorites
+	{
+		value := x.Favorites
+		if !(len(value) == 0) {
+			children := map[string]interface{}{}
+			for i := range value {
+				element := value[i]
+				if element == nil {
+					children[strconv.Itoa(i)] = []string{galidatorMessage(ctx, "non_nil", "", "", "", nil)}
+				} else if errors := element.Validate(ctx); len(errors) != 0 {
+					children[strconv.Itoa(i)] = errors
+				}
+			}
+			if len(children) != 0 {
+				output["favorites"] = children
+			}
+		}
+	}
+	// secret
+	{
+		value := x.secret
+		if !(value == "") {
+			var current interface{} = value
+			errors := []string{}
+			if !(len(value) >= 3) {
+				errors = append(errors, galidatorMessage(ctx, "min", "", "secret", "", current, "min", "3"))
+			}
+			if len(errors) != 0 {
+				output["secret"] = errors
+			}
+		}
+	}
+	// Friends
+	{
+		value := x.Friends
+		if !(len(value) == 0) {
+			children := map[string]interface{}{}
+			for i := range value {
+				element := value[i]
+				if element == nil {
+					children[strconv.Itoa(i)] = []string{galidatorMessage(ctx, "non_nil", "", "", "", nil)}
+				} else if errors := element.Validate(ctx); len(errors) != 0 {
+					children[strconv.Itoa(i)] = errors
+				}
+			}
+			if len(children) != 0 {
+				output["friends"] = children
+			}
+		}
+	}
+	// Bio
+	{
+		value := x.Bio
+		if !(value == "") {
+			var current interface{} = value
+			errors := []string{}
+			if !(len(value) <= 1000) {
+				errors = append(errors, galidatorMessage(ctx, "max", "{max, plural, one {# character} other {# characters}} at most, $$value is not shown", "bio", "", current, "max", "1000"))
+			}
+			if len(errors) != 0 {
+				output["bio"] = errors
+			}
+		}
+	}
+	// Phones
+	{
+		value := x.Phones
+		if !(len(value) == 0) {
+			children := map[string]interface{}{}
+			for i := range value {
+				element := value[i]
+				if !(element == "") {
+					errors := []string{}
+					if !(galidatorPhone(element)) {
+						errors = append(errors, galidatorMessage(ctx, "phone", "", "", "", "[REDACTED]"))
+					}
+					if len(errors) != 0 {
+						children[strconv.Itoa(i)] = errors
+					}
+				}
+			}
+			if len(children) != 0 {
+				output["phones"] = children
+			}
+		}
+	}
+	if len(output) == 0 {
+		return nil
+	}
+	return output
+}
+
+// galidatorIsZero returns true if x is the zero value of Address
+func (x *Address) galidatorIsZero() bool {
+	return (x.City == "" && x.Zip == nil && x.Country == "")
+}
+
+// Formats error message of passed rule key like galidator does
+func galidatorMessage(ctx context.Context, key, message, field, label string, value interface{}, options ...string) string {
+	params := map[string]string{}
+	for i := 0; i+1 < len(options); i += 2 {
+		params[options[i]] = options[i+1]
+	}
+	return G.FormatMessage(ctx, galidator.GeneratedMessage{Key: key, Message: message, Field: field, Label: label, Value: value, Options: params})
+}
+
+// Returns custom validator which is registered with passed key in the generator
+func galidatorCustom(key string) func(context.Context, interface{}) bool {
+	if validator := G.GetCustomValidator(key); validator != nil {
+		return validator
+	}
+	panic(fmt.Sprintf("%s custom validator did not find, call CustomValidators function before calling Validator function", key))
+}
+
+// Returns true if input is a valid email
+func galidatorEmail(input string) bool {
+	_, err := mail.ParseAddress(input)
+	return err == nil
+}
+
+// Returns true if input is a valid phone number
+func galidatorPhone(input string) bool {
+	if !galidatorMatch(galidatorRegex1, input) {
+		return false
+	}
+	parsedNumber, err := phonenumbers.Parse(input, "")
+	return err == nil && phonenumbers.IsValidNumber(parsedNumber)
+}
+
+// Returns true if input matches passed regex
+func galidatorMatch(regex *regexp2.Regexp, input string) bool {
+	output, _ := regex.MatchString(input)
+	return output
+}
@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+	"github.com/golodash/galidator/v2/tests/generated"
+)
+
+func TestGenerated(t *testing.T) {
+	validator := generated.G.Validator(generated.User{})
+	zip, shortZip := "12345", "123"
+	phone, invalidPhone := "+989123456789", "09123456789"
+	age, oldAge := 20, 200
+	one, zero := 1, 0
+	valid := func() *generated.User {
+		user := &generated.User{
+			Username: "username",
+			Email:    "m@g.com",
+			Phone:    &phone,
+			Password: "Password1!",
+			Age:      &age,
+			Score:    5,
+			Active:   true,
+			Tags:     []string{"tag"},
+			Numbers:  []*int{&one},
+			Contact:  "m@g.com",
+			Code:     "ab",
+			Nickname: "nick",
+			Home:     generated.Address{City: "Tehran", Zip: &zip, Country: "IR"},
+			Work:     &generated.Address{City: "Berlin", Country: "DE"},
+			Items:    []generated.Item{{Name: "item", Price: 1, Quantity: 2}},
+		}
+		user.SetSecret("secret")
+		return user
+	}
+
+	scenarios := []struct {
+		name string
+		in   *generated.User
+	}{
+		{name: "pass", in: valid()},
+		{name: "fail-zero", in: &generated.User{}},
+		{name: "fail-strings", in: func() *generated.User {
+			user := valid()
+			user.Username = "us"
+			user.Email = "invalid"
+			user.Phone = &invalidPhone
+			user.Password = "password"
+			user.Contact = "invalid"
+			user.Code = "abc"
+			user.Bio = strings.Repeat("a", 1001)
+			user.Phones = []string{invalidPhone}
+			user.SetSecret("s")
+			return user
+		}()},
+		{name: "fail-numbers", in: func() *generated.User {
+			user := valid()
+			user.Age = &oldAge
+			user.Score = 10.5
+			user.Active = false
+			return user
+		}()},
+		{name: "fail-requires", in: func() *generated.User {
+			user := valid()
+			user.Nickname = ""
+			user.Email = ""
+			user.Phone = nil
+			user.Contact = ""
+			return user
+		}()},
+		{name: "fail-pointer-nested", in: func() *generated.User {
+			user := valid()
+			user.Work = &generated.Address{City: "Berlin", Zip: &shortZip}
+			return user
+		}()},
+		{name: "fail-children", in: func() *generated.User {
+			user := valid()
+			user.Tags = []string{"t", "", "long tag value", "tag"}
+			user.Numbers = []*int{&one, &zero}
+			return user
+		}()},
+		{name: "fail-nested", in: func() *generated.User {
+			user := valid()
+			user.Home = generated.Address{Zip: &shortZip, Country: "FR"}
+			user.Work = &generated.Address{}
+			user.Items = []generated.Item{{Name: "i", Price: 0.1, Quantity: 1}, {}}
+			user.Favorites = []*generated.Item{nil, {Name: "item", Price: 1}}
+			user.Friends = []*generated.Address{{City: "Paris"}, {}}
+			return user
+		}()},
+		{name: "fail-nil-nested", in: func() *generated.User {
+			user := valid()
+			user.Work = nil
+			user.Items = []generated.Item{}
+			return user
+		}()},
+	}
+
+	galidator.RegisterCatalog("x-generated", galidator.Messages{"label.City name": "Generated city", "required": "$field is needed"})
+	contexts := map[string]context.Context{
+		"":            context.TODO(),
+		"-locale":     galidator.WithLocale(context.TODO(), "de"),
+		"-catalog":    galidator.WithLocale(context.TODO(), "x-generated"),
+		"-translator": galidator.WithTranslator(context.TODO(), strings.ToUpper),
+	}
+	for _, s := range scenarios {
+		for suffix, ctx := range contexts {
+			t.Run(s.name+suffix, func(t *testing.T) {
+				expected := normalizeErrors(validator.Validate(ctx, s.in))
+				output := normalizeErrors(s.in.Validate(ctx))
+				if !strings.HasPrefix(s.name, "pass") && expected == nil {
+					t.Fatalf("runtime validator found no errors")
+				}
+				check(t, expected, output)
+			})
+		}
+	}
+
+	t.Run("label", func(t *testing.T) {
+		address := &generated.Address{}
+		check(t, []string{"Generated city is required"}, address.Validate(galidator.WithLocale(context.TODO(), "x-generated"))["city"])
+		translator := func(input string) string {
+			return strings.ReplaceAll(input, "City name", "Translated city")
+		}
+		check(t, []string{"Translated city is required"}, address.Validate(galidator.WithTranslator(context.TODO(), translator))["city"])
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		defer galidator.TruncateValues(galidator.TruncateValues(5))
+
+		user := valid()
+		user.Tags = []string{"long tag value"}
+		expected := normalizeErrors(validator.Validate(context.TODO(), user))
+		check(t, expected, normalizeErrors(user.Validate(context.TODO())))
+		check(t, map[string]interface{}{"0": []string{"long ... length is invalid"}}, user.Validate(context.TODO())["tags"])
+	})
+
+	t.Run("template", func(t *testing.T) {
+		user := valid()
+		user.Bio = strings.Repeat("a", 1001)
+		check(t, []string{"1.000 characters at most, $value is not shown"}, user.Validate(galidator.WithLocale(context.TODO(), "de"))["bio"])
+	})
+}
+
+func BenchmarkValidateRuntime(b *testing.B) {
+	validator := generated.G.Validator(generated.User{})
+	in := &generated.User{Username: "username", Active: true, Work: &generated.Address{City: "Berlin"}, Items: []generated.Item{{Name: "item", Price: 1}}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		validator.Validate(context.TODO(), in)
+	}
+}
+
+func BenchmarkValidateGenerated(b *testing.B) {
+	in := &generated.User{Username: "username", Active: true, Work: &generated.Address{City: "Berlin"}, Items: []generated.Item{{Name: "item", Price: 1}}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		in.Validate(context.TODO())
+	}
+}
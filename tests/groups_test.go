@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type groupsUser struct {
+	ID       int    `json:"id" g:"required@update,min=1@update" required:"$field is required" min:"$field must be positive"`
+	Username string `json:"username" g:"required,len_range=3&16" required:"$field is required" len_range:"$field length is invalid"`
+	Password string `json:"password" g:"required@create|reset" required:"$field is required"`
+}
+
+func TestGroups(t *testing.T) {
+	complexValidator := g.ComplexValidator(galidator.Rules{
+		"ID": g.R("id").Required().Min(1).Groups("required", "update").Groups("min", "update").
+			SpecificMessages(galidator.Messages{"required": "$field is required", "min": "$field must be positive"}),
+		"Username": g.R("username").Required().LenRange(3, 16).
+			SpecificMessages(galidator.Messages{"required": "$field is required", "len_range": "$field length is invalid"}),
+		"Password": g.R("password").Required().Groups("required", "create", "reset").
+			SpecificMessages(galidator.Messages{"required": "$field is required"}),
+	})
+	tagValidator := g.Validator(groupsUser{})
+
+	for _, v := range []galidator.Validator{complexValidator, tagValidator} {
+		scenarios := []struct {
+			scenario
+			groups []string
+		}{
+			{
+				scenario: scenario{
+					name:      "pass-no-groups",
+					validator: v,
+					in:        groupsUser{Username: "username"},
+					panic:     false,
+					expected:  nil,
+				},
+				groups: nil,
+			},
+			{
+				scenario: scenario{
+					name:      "fail-no-groups",
+					validator: v,
+					in:        groupsUser{ID: -1, Username: "us"},
+					panic:     false,
+					expected:  map[string][]string{"username": {"username length is invalid"}},
+				},
+				groups: nil,
+			},
+			{
+				scenario: scenario{
+					name:      "pass-create",
+					validator: v,
+					in:        groupsUser{ID: -1, Username: "username", Password: "password"},
+					panic:     false,
+					expected:  nil,
+				},
+				groups: []string{"create"},
+			},
+			{
+				scenario: scenario{
+					name:      "fail-create",
+					validator: v,
+					in:        groupsUser{Username: "username"},
+					panic:     false,
+					expected:  map[string][]string{"password": {"password is required"}},
+				},
+				groups: []string{"create"},
+			},
+			{
+				scenario: scenario{
+					name:      "fail-update",
+					validator: v,
+					in:        &groupsUser{ID: -1, Username: "username"},
+					panic:     false,
+					expected:  map[string][]string{"id": {"id must be positive"}},
+				},
+				groups: []string{"update"},
+			},
+			{
+				scenario: scenario{
+					name:      "fail-update-reset",
+					validator: v,
+					in:        groupsUser{ID: -1, Username: "username"},
+					panic:     false,
+					expected:  map[string][]string{"id": {"id must be positive"}, "password": {"password is required"}},
+				},
+				groups: []string{"update", "reset"},
+			},
+		}
+
+		for _, s := range scenarios {
+			t.Run(s.name, func(t *testing.T) {
+				defer deferTestCases(t, s.panic, s.expected)
+
+				output := s.validator.ValidateGroups(context.TODO(), s.in, s.groups)
+				if !check(t, s.expected, output) {
+					return
+				}
+				output = s.validator.Validate(galidator.WithGroups(context.TODO(), s.groups...), s.in)
+				check(t, s.expected, output)
+			})
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type labeled struct {
+	FirstName string `json:"first_name" g:"required" required:"$field is required" label:"First name"`
+	LastName  string `json:"last_name" g:"required" required:"$field is required"`
+}
+
+func TestLabel(t *testing.T) {
+	galidator.RegisterCatalog("fa", galidator.Messages{"label.First name": "نام"})
+	labelTranslator := func(input string) string {
+		if input == "First name" {
+			return "Vorname"
+		}
+		return input
+	}
+
+	scenarios := []struct {
+		name       string
+		validator  galidator.Validator
+		ctx        context.Context
+		translator []galidator.Translator
+		in         interface{}
+		panic      bool
+		expected   interface{}
+	}{
+		{
+			name:      "builder",
+			validator: g.ComplexValidator(galidator.Rules{"FirstName": g.R("first_name").Required().Label("First name").SpecificMessages(galidator.Messages{"required": "$field is required"})}),
+			ctx:       context.TODO(),
+			in:        labeled{},
+			panic:     false,
+			expected:  map[string]interface{}{"first_name": []string{"First name is required"}},
+		},
+		{
+			name:      "tag",
+			validator: g.Validator(labeled{}),
+			ctx:       context.TODO(),
+			in:        labeled{},
+			panic:     false,
+			expected:  map[string]interface{}{"first_name": []string{"First name is required"}, "last_name": []string{"last_name is required"}},
+		},
+		{
+			name:      "catalog",
+			validator: galidator.G().Locale("fa").Validator(labeled{}),
+			ctx:       context.TODO(),
+			in:        labeled{},
+			panic:     false,
+			expected:  map[string]interface{}{"first_name": []string{"نام is required"}, "last_name": []string{"last_name is required"}},
+		},
+		{
+			name:      "catalog-from-context",
+			validator: g.Validator(labeled{}),
+			ctx:       galidator.WithLocale(context.TODO(), "fa-IR"),
+			in:        labeled{},
+			panic:     false,
+			expected:  map[string]interface{}{"first_name": []string{"نام is required"}, "last_name": []string{"last_name is required"}},
+		},
+		{
+			name:       "translator",
+			validator:  g.Validator(labeled{}),
+			ctx:        context.TODO(),
+			translator: []galidator.Translator{labelTranslator},
+			in:         labeled{},
+			panic:      false,
+			expected:   map[string]interface{}{"first_name": []string{"Vorname is required"}, "last_name": []string{"last_name is required"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(s.ctx, s.in, s.translator...)
+			check(t, s.expected, output)
+		})
+	}
+}
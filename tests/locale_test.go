@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+func TestContextLocale(t *testing.T) {
+	upper := func(input string) string {
+		return "[" + input + "]"
+	}
+	localized := func(ctx context.Context, input interface{}) bool {
+		return galidator.LocaleFromContext(ctx) == "de"
+	}
+
+	scenarios := []struct {
+		name      string
+		validator galidator.Validator
+		ctx       context.Context
+		in        interface{}
+		panic     bool
+		expected  interface{}
+	}{
+		{
+			name:      "locale",
+			validator: galidator.G().Validator(galidator.G().R().Required()),
+			ctx:       galidator.WithLocale(context.TODO(), "fr-FR"),
+			in:        "",
+			panic:     false,
+			expected:  []string{"obligatoire"},
+		},
+		{
+			name:      "locale-overrides-generator",
+			validator: galidator.G().Locale("fa").Validator(galidator.G().R().Required()),
+			ctx:       galidator.WithLocale(context.TODO(), "ru"),
+			in:        "",
+			panic:     false,
+			expected:  []string{"обязательное поле"},
+		},
+		{
+			name: "locale-nested",
+			validator: galidator.G().Validator(struct {
+				Name string `json:"name" g:"required"`
+			}{}),
+			ctx: galidator.WithLocale(context.TODO(), "es"),
+			in: struct {
+				Name string `json:"name" g:"required"`
+			}{},
+			panic:    false,
+			expected: map[string]interface{}{"name": []string{"obligatorio"}},
+		},
+		{
+			name:      "translator",
+			validator: galidator.G().Validator(galidator.G().R().Required()),
+			ctx:       galidator.WithTranslator(context.TODO(), upper),
+			in:        "",
+			panic:     false,
+			expected:  []string{"[required]"},
+		},
+		{
+			name:      "locale-and-translator",
+			validator: galidator.G().Validator(galidator.G().R().Required()),
+			ctx:       galidator.WithTranslator(galidator.WithLocale(context.TODO(), "de"), upper),
+			in:        "",
+			panic:     false,
+			expected:  []string{"[erforderlich]"},
+		},
+		{
+			name:      "custom-validator-pass",
+			validator: galidator.G().Validator(galidator.G().R().Custom(galidator.Validators{"localized": localized})),
+			ctx:       galidator.WithLocale(context.TODO(), "de"),
+			in:        1,
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "custom-validator-fail",
+			validator: galidator.G().Validator(galidator.G().R().Custom(galidator.Validators{"localized": localized})),
+			ctx:       context.TODO(),
+			in:        1,
+			panic:     false,
+			expected:  []string{"error happened but no error message exists on 'localized' rule key"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(s.ctx, s.in)
+			check(t, s.expected, output)
+		})
+	}
+
+	t.Run("argument-overrides-context", func(t *testing.T) {
+		v := galidator.G().Validator(galidator.G().R().Required())
+		output := v.Validate(galidator.WithTranslator(context.TODO(), upper), "", testTranslator)
+		check(t, []string{"this is required which is translated"}, output)
+	})
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	scenarios := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: "en"},
+		{header: "fa", expected: "fa"},
+		{header: "de-DE", expected: "de"},
+		{header: "fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5", expected: "fr"},
+		{header: "fr;q=0.5, de-AT;q=0.8", expected: "de"},
+		{header: "xx, zh-Hans-CN;q=0.3", expected: "zh"},
+		{header: "ru;q=0, es_MX;q=0.2", expected: "es"},
+		{header: "xx, *;q=0.1, ar;q=0.05", expected: "en"},
+		{header: "xx-YY, yy", expected: "en"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.header, func(t *testing.T) {
+			if output := galidator.ParseAcceptLanguage(s.header); output != s.expected {
+				t.Errorf("expected = %s, output = %s", s.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type planContextKey struct{}
+
+func planMessage(ctx context.Context, fieldName string, value interface{}, ruleKey string, options map[string]string) string {
+	plan, _ := ctx.Value(planContextKey{}).(string)
+	return fmt.Sprintf("$field must be at most %s items for your plan (%s)", options["max"], galidator.EscapeMessage(plan))
+}
+
+type messageFuncItems struct {
+	Items []int `json:"items" g:"max=3"`
+}
+
+func TestMessageFuncs(t *testing.T) {
+	ctx := context.WithValue(context.TODO(), planContextKey{}, "Basic")
+	echo := func(ctx context.Context, fieldName string, value interface{}, ruleKey string, options map[string]string) string {
+		return fmt.Sprintf("%s|%s|%v|%d", ruleKey, fieldName, value, len(options))
+	}
+	funcGenerator := galidator.G().CustomMessages(galidator.Messages{"required": "generator message"}).CustomMessageFuncs(galidator.MessageFuncs{"max": planMessage, "required": echo})
+	base := galidator.G().Validator(messageFuncItems{})
+
+	scenarios := []scenario{
+		{
+			name:      "generator",
+			validator: funcGenerator.Validator(messageFuncItems{}),
+			in:        messageFuncItems{Items: []int{1, 2, 3, 4}},
+			panic:     false,
+			expected:  map[string]interface{}{"items": []string{"items must be at most 3 items for your plan (Basic)"}},
+		},
+		{
+			name:      "generator-func-wins-over-generator-message",
+			validator: funcGenerator.Validator(g.R().Required()),
+			in:        "",
+			panic:     false,
+			expected:  []string{"required|||0"},
+		},
+		{
+			name:      "validator-message-wins-over-generator-func",
+			validator: funcGenerator.Validator(g.R().Required(), galidator.Messages{"required": "validator message"}),
+			in:        "",
+			panic:     false,
+			expected:  []string{"validator message"},
+		},
+		{
+			name:      "validator",
+			validator: base.WithMessageFuncs(galidator.MessageFuncs{"max": planMessage}),
+			in:        messageFuncItems{Items: []int{1, 2, 3, 4}},
+			panic:     false,
+			expected:  map[string]interface{}{"items": []string{"items must be at most 3 items for your plan (Basic)"}},
+		},
+		{
+			name:      "validator-is-not-changed",
+			validator: base,
+			in:        messageFuncItems{Items: []int{1, 2, 3, 4}},
+			panic:     false,
+			expected:  map[string]interface{}{"items": []string{"items's length must be lower equal to 3"}},
+		},
+		{
+			name:      "specific",
+			validator: g.Validator(g.R().Min(2).SpecificMessageFuncs(galidator.MessageFuncs{"min": echo})),
+			in:        "a",
+			panic:     false,
+			expected:  []string{"min||a|1"},
+		},
+		{
+			name:      "specific-message-wins-over-validator-func",
+			validator: g.Validator(g.R().Min(2).SpecificMessages(galidator.Messages{"min": "specific"})).WithMessageFuncs(galidator.MessageFuncs{"min": echo}),
+			in:        "a",
+			panic:     false,
+			expected:  []string{"specific"},
+		},
+		{
+			name: "nested",
+			validator: g.Validator(struct {
+				Users []messageFuncItems `json:"users" g:"required"`
+			}{}).WithMessageFuncs(galidator.MessageFuncs{"max": planMessage}),
+			in: struct {
+				Users []messageFuncItems `json:"users" g:"required"`
+			}{Users: []messageFuncItems{{Items: []int{1, 2, 3, 4}}}},
+			panic:    false,
+			expected: map[string]interface{}{"users": map[string]interface{}{"0": map[string]interface{}{"items": []string{"items must be at most 3 items for your plan (Basic)"}}}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(ctx, s.in)
+			check(t, s.expected, output)
+		})
+	}
+
+	t.Run("escaped", func(t *testing.T) {
+		ctx := context.WithValue(context.TODO(), planContextKey{}, "{max, number} $field #1")
+		v := g.Validator(messageFuncItems{}).WithMessageFuncs(galidator.MessageFuncs{"max": planMessage})
+		check(t, map[string]interface{}{"items": []string{"items must be at most 3 items for your plan ({max, number} $field #1)"}}, v.Validate(ctx, messageFuncItems{Items: []int{1, 2, 3, 4}}))
+	})
+
+	t.Run("translated", func(t *testing.T) {
+		v := g.Validator(g.R().Required()).WithMessageFuncs(galidator.MessageFuncs{"required": func(ctx context.Context, fieldName string, value interface{}, ruleKey string, options map[string]string) string {
+			return "required"
+		}})
+		check(t, []string{"this is required which is translated"}, v.Validate(ctx, "", testTranslator))
+	})
+}
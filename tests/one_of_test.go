@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type oneOfPayment struct {
+	Type       string `json:"type"`
+	CardNumber string `json:"card_number"`
+	IBAN       string `json:"iban"`
+}
+
+func TestOneOf(t *testing.T) {
+	mapValidator := g.Validator(g.R().OneOf("type", galidator.Variants{
+		"card": {
+			"card_number": g.R().Required().Len(16),
+		},
+		"bank": {
+			"iban": g.R().Required().String(),
+		},
+	}))
+	structValidator := g.Validator(g.R().OneOf("type", galidator.Variants{
+		"card": {
+			"CardNumber": g.R("card_number").Required().Len(16),
+		},
+		"bank": {
+			"IBAN": g.R("iban").Required().String(),
+		},
+	}))
+	nestedValidator := g.ComplexValidator(galidator.Rules{
+		"payment": g.R().Required().OneOf("type", galidator.Variants{
+			"card": {
+				"card_number": g.R().Required().Len(16),
+			},
+		}),
+	})
+
+	scenarios := []scenario{
+		{
+			name:      "pass-map-card",
+			validator: mapValidator,
+			in:        map[string]interface{}{"type": "card", "card_number": "1234123412341234"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "pass-map-bank",
+			validator: mapValidator,
+			in:        map[string]interface{}{"type": "bank", "iban": "IR000000000000000000000000"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-map-card",
+			validator: mapValidator,
+			in:        map[string]interface{}{"type": "card", "card_number": "1234"},
+			panic:     false,
+			expected:  map[string][]string{"card_number": {"card_number's length must be equal to 16"}},
+		},
+		{
+			name:      "fail-map-unknown",
+			validator: mapValidator,
+			in:        map[string]interface{}{"type": "crypto"},
+			panic:     false,
+			expected:  map[string][]string{"type": {"type must be one of [bank, card]"}},
+		},
+		{
+			name:      "fail-map-missing",
+			validator: mapValidator,
+			in:        map[string]interface{}{"iban": "IR000000000000000000000000"},
+			panic:     false,
+			expected:  map[string][]string{"type": {"type must be one of [bank, card]"}},
+		},
+		{
+			name:      "pass-struct",
+			validator: structValidator,
+			in:        oneOfPayment{Type: "bank", IBAN: "IR000000000000000000000000"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-struct",
+			validator: structValidator,
+			in:        &oneOfPayment{Type: "bank", CardNumber: "1234123412341234"},
+			panic:     false,
+			expected:  map[string][]string{"iban": {"required"}},
+		},
+		{
+			name:      "fail-struct-unknown",
+			validator: structValidator,
+			in:        oneOfPayment{Type: "cash"},
+			panic:     false,
+			expected:  map[string][]string{"type": {"type must be one of [bank, card]"}},
+		},
+		{
+			name:      "fail-nested",
+			validator: nestedValidator,
+			in:        map[string]interface{}{"payment": map[string]interface{}{"type": "bank"}},
+			panic:     false,
+			expected:  map[string]map[string][]string{"payment": {"type": {"type must be one of [card]"}}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+
+	t.Run("get-variants", func(t *testing.T) {
+		union := mapValidator.GetRule().GetValidator()
+		variants := union.GetVariants()
+		delete(variants, "card")
+		check(t, 2, len(union.GetVariants()))
+		check(t, true, union.GetVariants()["card"] != union.GetVariants()["card"])
+	})
+}
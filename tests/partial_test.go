@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type partialAddress struct {
+	City string `json:"city" g:"required" required:"$field is required"`
+	Zip  string `json:"zip" g:"required,len=5" required:"$field is required" len:"$field must be 5 characters long"`
+}
+
+type partialArticle struct {
+	Title   *string         `json:"title" g:"required,len_range=3&32" required:"$field is required" len_range:"$field length is invalid"`
+	Content *string         `json:"content" g:"required" required:"$field is required"`
+	Author  string          `json:"author" g:"required" required:"$field is required"`
+	Address *partialAddress `json:"address" g:"required" required:"$field is required"`
+}
+
+func TestPartial(t *testing.T) {
+	short := "ab"
+	title := "title"
+	mapValidator := g.ComplexValidator(galidator.Rules{
+		"title":   g.R().Required().String().LenRange(3, 32).SpecificMessages(galidator.Messages{"len_range": "$field length is invalid"}),
+		"content": g.R().Required().String(),
+		"author":  g.R().WhenExistOne("title").String(),
+	})
+	structValidator := g.Validator(partialArticle{})
+
+	scenarios := []scenario{
+		{
+			name:      "pass-map-empty",
+			validator: mapValidator,
+			in:        map[string]interface{}{},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-map",
+			validator: mapValidator,
+			in:        map[string]interface{}{"title": "ab"},
+			panic:     false,
+			expected:  map[string][]string{"title": {"title length is invalid"}},
+		},
+		{
+			name:      "fail-map-present-empty",
+			validator: mapValidator,
+			in:        map[string]interface{}{"content": ""},
+			panic:     false,
+			expected:  map[string][]string{"content": {"required"}},
+		},
+		{
+			name:      "pass-struct-nil-pointers",
+			validator: structValidator,
+			in:        partialArticle{Author: "me"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-struct",
+			validator: structValidator,
+			in:        &partialArticle{Title: &short, Author: "me"},
+			panic:     false,
+			expected:  map[string][]string{"title": {"title length is invalid"}},
+		},
+		{
+			name:      "fail-struct-not-pointer",
+			validator: structValidator,
+			in:        partialArticle{Title: &title},
+			panic:     false,
+			expected:  map[string][]string{"author": {"author is required"}},
+		},
+		{
+			name:      "fail-struct-nested",
+			validator: structValidator,
+			in:        partialArticle{Author: "me", Address: &partialAddress{Zip: "123"}},
+			panic:     false,
+			expected:  map[string]map[string][]string{"address": {"city": {"city is required"}, "zip": {"zip must be 5 characters long"}}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.ValidatePartial(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+
+	t.Run("validate-pointer-nested", func(t *testing.T) {
+		in := partialArticle{Title: &title, Content: &title, Author: "me", Address: &partialAddress{Zip: "123"}}
+		expected := map[string]interface{}{"address": map[string]interface{}{"city": []string{"city is required"}, "zip": []string{"zip must be 5 characters long"}}}
+		check(t, expected, structValidator.Validate(context.TODO(), in))
+		check(t, expected, structValidator.ValidatePartial(context.TODO(), in))
+	})
+}
+
+func TestPartialPresentKeys(t *testing.T) {
+	validator := g.Validator(partialArticle{})
+
+	scenarios := []struct {
+		scenario
+		body string
+	}{
+		{
+			scenario: scenario{
+				name:      "pass-empty",
+				validator: validator,
+				panic:     false,
+				expected:  nil,
+			},
+			body: `{}`,
+		},
+		{
+			scenario: scenario{
+				name:      "fail-null",
+				validator: validator,
+				panic:     false,
+				expected:  map[string][]string{"content": {"content is required"}},
+			},
+			body: `{"content": null}`,
+		},
+		{
+			scenario: scenario{
+				name:      "fail-empty-string",
+				validator: validator,
+				panic:     false,
+				expected:  map[string][]string{"author": {"author is required"}},
+			},
+			body: `{"author": ""}`,
+		},
+		{
+			scenario: scenario{
+				name:      "fail-nested",
+				validator: validator,
+				panic:     false,
+				expected:  map[string]map[string][]string{"address": {"zip": {"zip must be 5 characters long"}}},
+			},
+			body: `{"address": {"zip": "123"}}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			input := &partialArticle{}
+			if err := json.Unmarshal([]byte(s.body), input); err != nil {
+				t.Fatal(err)
+			}
+			keys, err := galidator.JSONKeys([]byte(s.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output := s.validator.ValidatePartial(galidator.WithPresentKeys(context.TODO(), keys...), input)
+			check(t, s.expected, output)
+		})
+	}
+}
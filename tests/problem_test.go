@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golodash/galidator/v2"
+)
+
+type problemAddress struct {
+	Zip string `json:"zip" g:"required,len=5" required:"$field is required" len:"$field must have $length characters"`
+}
+
+type problemUser struct {
+	Name      string           `json:"name" g:"required" required:"$field is required"`
+	Addresses []problemAddress `json:"addresses"`
+	Weird     string           `json:"a/b~c" g:"min=2" min:"too short"`
+}
+
+var problemValidator = g.Validator(problemUser{})
+
+func TestProblem(t *testing.T) {
+	invalid := problemUser{Addresses: []problemAddress{{Zip: "12345"}, {Zip: "123"}}, Weird: "a"}
+
+	scenarios := []struct {
+		name     string
+		options  []galidator.ProblemOptions
+		in       interface{}
+		expected *galidator.Problem
+	}{
+		{
+			name:     "valid",
+			in:       problemUser{Name: "a"},
+			expected: nil,
+		},
+		{
+			name: "json-pointer",
+			in:   invalid,
+			expected: &galidator.Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: 400,
+				InvalidParams: []galidator.InvalidParam{
+					{Name: "/a~1b~0c", Reason: "too short", Rule: "min"},
+					{Name: "/addresses/1/zip", Reason: "zip must have 5 characters", Rule: "len"},
+					{Name: "/name", Reason: "name is required", Rule: "required"},
+				},
+				Errors: map[string]interface{}{
+					"a/b~c":     []string{"too short"},
+					"addresses": map[string]interface{}{"1": map[string]interface{}{"zip": []string{"zip must have 5 characters"}}},
+					"name":      []string{"name is required"},
+				},
+			},
+		},
+		{
+			name: "dotted-path",
+			options: []galidator.ProblemOptions{{
+				Type:       "https://example.com/problems/validation",
+				Title:      "Validation failed",
+				Status:     422,
+				Detail:     "request body is invalid",
+				Instance:   "/users",
+				PathFormat: galidator.DottedPath,
+			}},
+			in: problemUser{Name: "a", Addresses: []problemAddress{{}}},
+			expected: &galidator.Problem{
+				Type:     "https://example.com/problems/validation",
+				Title:    "Validation failed",
+				Status:   422,
+				Detail:   "request body is invalid",
+				Instance: "/users",
+				InvalidParams: []galidator.InvalidParam{
+					{Name: "addresses.0.zip", Reason: "zip must have 5 characters", Rule: "len"},
+					{Name: "addresses.0.zip", Reason: "zip is required", Rule: "required"},
+				},
+				Errors: map[string]interface{}{"addresses": map[string]interface{}{"0": map[string]interface{}{"zip": []string{"zip must have 5 characters", "zip is required"}}}},
+			},
+		},
+		{
+			name:    "translator",
+			options: []galidator.ProblemOptions{{Translator: func(input string) string { return "translated: " + input }}},
+			in:      problemUser{Name: "a", Weird: "a"},
+			expected: &galidator.Problem{
+				Type:          "about:blank",
+				Title:         "Bad Request",
+				Status:        400,
+				InvalidParams: []galidator.InvalidParam{{Name: "/a~1b~0c", Reason: "translated: too short", Rule: "min"}},
+				Errors:        map[string]interface{}{"a/b~c": []string{"translated: too short"}},
+			},
+		},
+		{
+			name:    "omit-errors",
+			options: []galidator.ProblemOptions{{OmitErrors: true}},
+			in:      problemUser{Name: "a", Weird: "a"},
+			expected: &galidator.Problem{
+				Type:          "about:blank",
+				Title:         "Bad Request",
+				Status:        400,
+				InvalidParams: []galidator.InvalidParam{{Name: "/a~1b~0c", Reason: "too short", Rule: "min"}},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, false, s.expected)
+
+			output, err := problemValidator.Problem(context.TODO(), s.in, s.options...)
+			if err != nil {
+				t.Errorf("err = %s", err)
+			}
+			if s.expected == nil || output == nil {
+				check(t, true, s.expected == output)
+				return
+			}
+			expected, _ := json.Marshal(s.expected)
+			actual, _ := json.Marshal(output)
+			check(t, string(expected), string(actual))
+		})
+	}
+
+	t.Run("root", func(t *testing.T) {
+		output, _ := g.Validator(g.R().Min(3).SpecificMessages(galidator.Messages{"min": "$value is too short"})).Problem(context.TODO(), "ab", galidator.ProblemOptions{PathFormat: galidator.DottedPath})
+		check(t, []galidator.InvalidParam{{Name: "", Reason: "ab is too short", Rule: "min"}}, output.InvalidParams)
+	})
+
+	t.Run("new-problem", func(t *testing.T) {
+		errors := problemValidator.Validate(context.TODO(), invalid)
+		output := galidator.NewProblem(errors)
+		check(t, galidator.InvalidParam{Name: "/name", Reason: "name is required"}, output.InvalidParams[2])
+		check(t, true, galidator.NewProblem(nil) == nil)
+	})
+
+	t.Run("nested-validate", func(t *testing.T) {
+		inner := g.Validator(g.R().Min(3).SpecificMessages(galidator.Messages{"min": "inner"}))
+		var innerOutput interface{}
+		outer := g.Validator(g.R().Custom(galidator.Validators{"outer": func(ctx context.Context, input interface{}) bool {
+			innerOutput = inner.Validate(ctx, input)
+			return false
+		}}).SpecificMessages(galidator.Messages{"outer": "outer"}))
+		output, _ := outer.Problem(context.TODO(), "ab")
+		check(t, []galidator.InvalidParam{{Name: "", Reason: "outer", Rule: "outer"}}, output.InvalidParams)
+		check(t, []string{"inner"}, innerOutput)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		output, err := problemValidator.Problem(ctx, invalid)
+		check(t, true, output == nil)
+		check(t, true, galidator.IsCanceled(err))
+	})
+}
+
+func TestWriteProblem(t *testing.T) {
+	expected := `{"type":"about:blank","title":"Bad Request","status":400,"invalid_params":[{"name":"/name","reason":"name is required","rule":"required"}],"errors":{"name":["name is required"]}}`
+	handle := func(w http.ResponseWriter, r *http.Request) {
+		problem, _ := problemValidator.Problem(r.Context(), problemUser{})
+		galidator.WriteProblem(w, problem)
+	}
+	read := func(w *httptest.ResponseRecorder) {
+		body, _ := io.ReadAll(w.Body)
+		check(t, 400, w.Code)
+		check(t, galidator.ProblemContentType, w.Header().Get("Content-Type"))
+		check(t, expected, string(body))
+	}
+
+	t.Run("net-http", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handle).ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+		read(w)
+	})
+
+	t.Run("gin", func(t *testing.T) {
+		gin.SetMode(gin.ReleaseMode)
+		r := gin.New()
+		called := false
+		r.POST("/", func(c *gin.Context) {
+			problem, _ := problemValidator.Problem(c.Request.Context(), problemUser{})
+			galidator.AbortWithProblem(c, problem)
+		}, func(c *gin.Context) {
+			called = true
+		})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+		read(w)
+		check(t, false, called)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		problem := &galidator.Problem{}
+		data, _ := json.Marshal(galidator.NewProblem(map[string]interface{}{"name": []string{"bad"}}))
+		json.Unmarshal(data, problem)
+		check(t, map[string]interface{}{"name": []interface{}{"bad"}}, problem.Errors)
+	})
+}
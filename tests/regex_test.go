@@ -2,7 +2,9 @@ package tests
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golodash/galidator/v2"
 )
@@ -34,3 +36,96 @@ func TestRegex(t *testing.T) {
 		})
 	}
 }
+
+type regexTagged struct {
+	Code string `json:"code" g:"regex=^[a-z]{2}&[0-9]+$" regex:"$field is invalid"`
+}
+
+type regexInvalidTagged struct {
+	Code string `json:"code" g:"regex=(abc"`
+}
+
+func TestRegexTag(t *testing.T) {
+	v := g.Validator(regexTagged{})
+
+	scenarios := []scenario{
+		{
+			name:      "pass",
+			validator: v,
+			in:        regexTagged{Code: "ab&12"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail",
+			validator: v,
+			in:        regexTagged{Code: "ab12"},
+			panic:     false,
+			expected:  map[string][]string{"code": {"code is invalid"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
+
+func TestRegexBuildError(t *testing.T) {
+	scenarios := []struct {
+		name  string
+		input interface{}
+	}{
+		{name: "ruleSet", input: g.R().Regex("(abc")},
+		{name: "nested", input: g.R().Complex(galidator.Rules{"Code": g.R().Regex("[a-")})},
+		{name: "or", input: g.R().OR(g.R().Int(), g.R().Regex("(abc"))},
+		{name: "then", input: g.R().When(func(context.Context, interface{}) bool { return true }).Then(g.R().Regex("(abc"))},
+		{name: "tag", input: regexInvalidTagged{}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			v, err := g.Build(s.input)
+			if v != nil {
+				t.Errorf("expected no validator, got %v", v)
+			}
+			buildError, ok := err.(*galidator.BuildError)
+			if !ok || len(buildError.Errors) != 1 || !strings.Contains(err.Error(), "invalid regex pattern") {
+				t.Errorf("expected a build error of an invalid regex pattern, got %v", err)
+			}
+		})
+	}
+
+	t.Run("validator-panics", func(t *testing.T) {
+		defer func() {
+			if _, ok := recover().(*galidator.BuildError); !ok {
+				t.Error("expected panic with a build error")
+			}
+		}()
+		g.Validator(g.R().Regex("(abc"))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		v, err := g.Build(g.R().Regex("^123$"))
+		if err != nil || v == nil {
+			t.Errorf("expected a validator, got %v", err)
+		}
+	})
+}
+
+func TestRegexTimeout(t *testing.T) {
+	tg := galidator.New().RegexTimeout(10 * time.Millisecond)
+	v := tg.Validator(tg.R().Regex(`^(a+)+$`).SpecificMessages(galidator.Messages{"regex": "regex failed"}))
+
+	start := time.Now()
+	output := v.Validate(context.TODO(), strings.Repeat("a", 40)+"!")
+	if time.Since(start) > time.Second {
+		t.Errorf("matching did not time out")
+	}
+	check(t, []string{"regex failed"}, output)
+	check(t, nil, v.Validate(context.TODO(), "aaaa"))
+}
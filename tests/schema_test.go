@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type schemaAddress struct {
+	Zip string `json:"zip" g:"required,len=5"`
+}
+
+type schemaUser struct {
+	Name      string          `json:"name" g:"required,string,len_range=3&32" label:"Full name"`
+	Email     string          `json:"email" g:"email"`
+	Age       int             `json:"age" g:"int,min=18"`
+	Code      string          `json:"code" g:"regex=^[a-z]+$"`
+	Addresses []schemaAddress `json:"addresses" g:"max=3"`
+	Score     *int            `json:"score" g:"max=10"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	scenarios := []scenario{
+		{
+			name: "one-of",
+			validator: g.Validator(g.R().OneOf("type", galidator.Variants{
+				"card": {"CardNumber": g.R("card_number").Required().Len(16)},
+				"bank": {"IBAN": g.R("iban").String()},
+			})),
+			expected: `{"discriminator":{"propertyName":"type"},"oneOf":[` +
+				`{"properties":{"iban":{"type":"string"},"type":{"const":"bank"}},"required":["type"],"type":"object"},` +
+				`{"properties":{"card_number":{"maxItems":16,"maxLength":16,"maxProperties":16,"minItems":16,"minLength":16,"minProperties":16},"type":{"const":"card"}},"required":["type","card_number"],"type":"object"}` +
+				`],"type":"object"}`,
+		},
+		{
+			name:      "struct",
+			validator: g.Validator(schemaUser{}),
+			expected: `{"properties":{` +
+				`"addresses":{"items":{"properties":{"zip":{"maxLength":5,"minLength":5}},"required":["zip"],"type":"object"},"maxItems":3,"type":"array"},` +
+				`"age":{"minimum":18,"type":"integer"},` +
+				`"code":{"pattern":"^[a-z]+$"},` +
+				`"email":{"format":"email"},` +
+				`"name":{"maxLength":32,"minLength":3,"title":"Full name","type":"string"},` +
+				`"score":{"maximum":10}` +
+				`},"required":["name"],"type":"object"}`,
+		},
+		{
+			name:      "ruleSet",
+			validator: g.Validator(g.R().Min(-5)),
+			expected:  `{"minimum":-5}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output, _ := json.Marshal(s.validator.JSONSchema())
+			check(t, s.expected, string(output))
+		})
+	}
+}
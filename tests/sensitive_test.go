@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type sensitiveTagged struct {
+	Token  string   `json:"token" g:"regex=^[a-f0-9]{8}$,sensitive"`
+	Cards  []string `json:"cards" g:"c.len=16,c.sensitive" c.len:"$value is invalid"`
+	Phones []string `json:"phones" g:"c.phone"`
+	Keys   []string `json:"keys" g:"sensitive,c.len=16" c.len:"$value is invalid"`
+	Owner  struct {
+		Pin string `json:"pin" g:"len=4" len:"$value is invalid"`
+	} `json:"owner" g:"sensitive"`
+}
+
+func TestSensitive(t *testing.T) {
+	echo := func(ctx context.Context, fieldName string, value interface{}, ruleKey string, options map[string]string) string {
+		return fmt.Sprint(value)
+	}
+
+	scenarios := []scenario{
+		{
+			name:      "builder",
+			validator: g.Validator(g.R().Phone().Sensitive()),
+			in:        "09123456789",
+			panic:     false,
+			expected:  []string{"[REDACTED] is not a valid international phone number format"},
+		},
+		{
+			name:      "not-sensitive",
+			validator: g.Validator(g.R().Phone()),
+			in:        "09123456789",
+			panic:     false,
+			expected:  []string{"09123456789 is not a valid international phone number format"},
+		},
+		{
+			name:      "message-func",
+			validator: g.Validator(g.R().Min(5).Sensitive().SpecificMessageFuncs(galidator.MessageFuncs{"min": echo})),
+			in:        "abc",
+			panic:     false,
+			expected:  []string{"[REDACTED]"},
+		},
+		{
+			name:      "tag",
+			validator: g.Validator(sensitiveTagged{}),
+			in: func() sensitiveTagged {
+				in := sensitiveTagged{Token: "secret-token", Cards: []string{"1234"}, Phones: []string{"0912"}, Keys: []string{"1234"}}
+				in.Owner.Pin = "123"
+				return in
+			}(),
+			panic: false,
+			expected: map[string]interface{}{
+				"token":  []string{"[REDACTED] does not pass /^[a-f0-9]{8}$/ pattern"},
+				"cards":  map[string]interface{}{"0": []string{"[REDACTED] is invalid"}},
+				"phones": map[string]interface{}{"0": []string{"0912 is not a valid international phone number format"}},
+				"keys":   map[string]interface{}{"0": []string{"[REDACTED] is invalid"}},
+				"owner":  map[string]interface{}{"pin": []string{"[REDACTED] is invalid"}},
+			},
+		},
+		{
+			name:      "map",
+			validator: g.Validator(g.R().Complex(galidator.Rules{"secret": g.R().Phone()}).Sensitive()),
+			in:        map[string]interface{}{"secret": "09123456789"},
+			panic:     false,
+			expected:  map[string]interface{}{"secret": []string{"[REDACTED] is not a valid international phone number format"}},
+		},
+		{
+			name: "branch",
+			validator: g.Validator(g.R().When(func(ctx context.Context, parent interface{}) bool {
+				return true
+			}).Then(g.R().Phone()).Sensitive()),
+			in:       "09123456789",
+			panic:    false,
+			expected: []string{"[REDACTED] is not a valid international phone number format"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
+
+func TestTruncateValues(t *testing.T) {
+	defer galidator.TruncateValues(galidator.TruncateValues(5))
+
+	scenarios := []scenario{
+		{
+			name:      "long",
+			validator: g.Validator(g.R().Phone()),
+			in:        "09123456789",
+			panic:     false,
+			expected:  []string{"09123... is not a valid international phone number format"},
+		},
+		{
+			name:      "multibyte",
+			validator: g.Validator(g.R().Email().SpecificMessages(galidator.Messages{"email": "$value is not an email"})),
+			in:        "ایمیل نامعتبر",
+			panic:     false,
+			expected:  []string{"ایمیل... is not an email"},
+		},
+		{
+			name:      "short",
+			validator: g.Validator(g.R().Phone()),
+			in:        "0912",
+			panic:     false,
+			expected:  []string{"0912 is not a valid international phone number format"},
+		},
+		{
+			name:      "sensitive",
+			validator: g.Validator(g.R().Phone().Sensitive()),
+			in:        "09123456789",
+			panic:     false,
+			expected:  []string{"[REDACTED] is not a valid international phone number format"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"context"
+	"testing"
+)
+
+type when struct {
+	Country string `json:"country"`
+	Zip     string `json:"zip" g:"when=country&US&CA,then.required,then.len=5,otherwise.len_range=2&10" then.len:"$field must be $length characters long in this country" otherwise.len_range:"$field is not valid ($branch)"`
+}
+
+func TestWhen(t *testing.T) {
+	scenarios := []scenario{
+		{
+			name:      "pass-then",
+			validator: g.Validator(when{}),
+			in:        when{Country: "US", Zip: "12345"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-then",
+			validator: g.Validator(when{}),
+			in:        when{Country: "CA", Zip: "1234"},
+			panic:     false,
+			expected:  map[string][]string{"zip": {"zip must be 5 characters long in this country"}},
+		},
+		{
+			name:      "pass-otherwise",
+			validator: g.Validator(when{}),
+			in:        when{Country: "IR", Zip: "1234"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-otherwise",
+			validator: g.Validator(when{}),
+			in:        when{Country: "IR", Zip: "1"},
+			panic:     false,
+			expected:  map[string][]string{"zip": {"zip is not valid (otherwise)"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
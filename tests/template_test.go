@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+func TestTemplate(t *testing.T) {
+	plural := galidator.Messages{"min": "$value must have at least {min, plural, =0 {no characters} one {# character} other {# characters}}"}
+	pluralRu := galidator.Messages{"min": "{min, plural, one {# символ} few {# символа} many {# символов} other {# символа}}"}
+
+	scenarios := []struct {
+		name      string
+		validator galidator.Validator
+		locale    string
+		in        interface{}
+		panic     bool
+		expected  interface{}
+	}{
+		{
+			name:      "placeholders",
+			validator: g.Validator(g.R().Min(3)),
+			in:        "ab",
+			panic:     false,
+			expected:  []string{"'s length must be higher equal to 3"},
+		},
+		{
+			name:      "plural-one",
+			validator: g.Validator(g.R().Min(1).SpecificMessages(plural)),
+			in:        0,
+			panic:     false,
+			expected:  []string{"0 must have at least 1 character"},
+		},
+		{
+			name:      "plural-other",
+			validator: g.Validator(g.R().Min(5).SpecificMessages(plural)),
+			in:        "ab",
+			panic:     false,
+			expected:  []string{"ab must have at least 5 characters"},
+		},
+		{
+			name:      "plural-float",
+			validator: g.Validator(g.R().Min(1.5).SpecificMessages(plural)),
+			in:        1,
+			panic:     false,
+			expected:  []string{"1 must have at least 1.5 characters"},
+		},
+		{
+			name:      "plural-ru-few",
+			validator: g.Validator(g.R().Min(3).SpecificMessages(pluralRu)),
+			locale:    "ru",
+			in:        "ab",
+			panic:     false,
+			expected:  []string{"3 символа"},
+		},
+		{
+			name:      "plural-ru-many",
+			validator: g.Validator(g.R().Min(11).SpecificMessages(pluralRu)),
+			locale:    "ru",
+			in:        "ab",
+			panic:     false,
+			expected:  []string{"11 символов"},
+		},
+		{
+			name:      "plural-ru-one",
+			validator: g.Validator(g.R().Min(21).SpecificMessages(pluralRu)),
+			locale:    "ru",
+			in:        "ab",
+			panic:     false,
+			expected:  []string{"21 символ"},
+		},
+		{
+			name:      "number-en",
+			validator: g.Validator(g.R().Max(1234567.5).SpecificMessages(galidator.Messages{"max": "at most {max, number}"})),
+			in:        2000000,
+			panic:     false,
+			expected:  []string{"at most 1,234,567.5"},
+		},
+		{
+			name:      "number-de",
+			validator: g.Validator(g.R().Max(1234567.5).SpecificMessages(galidator.Messages{"max": "höchstens {max, number}"})),
+			locale:    "de-DE",
+			in:        2000000,
+			panic:     false,
+			expected:  []string{"höchstens 1.234.567,5"},
+		},
+		{
+			name:      "number-fa",
+			validator: g.Validator(g.R().Max(1250).SpecificMessages(galidator.Messages{"max": "حداکثر {max, number}"})),
+			locale:    "fa",
+			in:        2000,
+			panic:     false,
+			expected:  []string{"حداکثر ۱٬۲۵۰"},
+		},
+		{
+			name:      "number-not-plain",
+			validator: g.Validator(g.R().Len(2).SpecificMessages(galidator.Messages{"len": "{value, number} is invalid"})),
+			in:        "1e+21",
+			panic:     false,
+			expected:  []string{"1e+21 is invalid"},
+		},
+		{
+			name:      "number-not-finite",
+			validator: g.Validator(g.R().Len(2).SpecificMessages(galidator.Messages{"len": "{value, number} is invalid"})),
+			in:        "Infinity",
+			panic:     false,
+			expected:  []string{"Infinity is invalid"},
+		},
+		{
+			name:      "select",
+			validator: g.Validator(g.R().Type(0).SpecificMessages(galidator.Messages{"type": "must be {type, select, int {an integer} other {a $type}}"})),
+			in:        "1",
+			panic:     false,
+			expected:  []string{"must be an integer"},
+		},
+		{
+			name:      "select-other",
+			validator: g.Validator(g.R().Type(true).SpecificMessages(galidator.Messages{"type": "must be {type, select, int {an integer} other {a $type}}"})),
+			in:        "1",
+			panic:     false,
+			expected:  []string{"must be a bool"},
+		},
+		{
+			name:      "escape",
+			validator: g.Validator(g.R().Min(5).SpecificMessages(galidator.Messages{"min": "costs $$$min, ${min, number$} and $value$#"})),
+			in:        4,
+			panic:     false,
+			expected:  []string{"costs $5, {min, number} and 4#"},
+		},
+		{
+			name:      "invalid-block-is-literal",
+			validator: g.Validator(g.R().Min(5).SpecificMessages(galidator.Messages{"min": "{unknown, number} {min} {min, plural, one} {2}"})),
+			in:        4,
+			panic:     false,
+			expected:  []string{"{unknown, number} {min} {min, plural, one} {2}"},
+		},
+		{
+			name:      "values-are-not-templates",
+			validator: g.Validator(g.R().Regex("^[a-z]{2}$")),
+			in:        "{min, number}$$",
+			panic:     false,
+			expected:  []string{"{min, number}$$ does not pass /^[a-z]{2}$/ pattern"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output := s.validator.Validate(galidator.WithLocale(context.TODO(), s.locale), s.in)
+			check(t, s.expected, output)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type unexportedTest struct {
+	A      string `json:"a" g:"required"`
+	b      string `g:"required"`
+	C      string `json:"c" g:"required"`
+	d      string `g:"required"`
+	E      string `json:"e" g:"required"`
+	secret string `g:"allow_unexported,min=3" min:"$field is too short"`
+}
+
+func TestUnexported(t *testing.T) {
+	complexValidator := g.ComplexValidator(galidator.Rules{
+		"A":      g.R("a").Required(),
+		"b":      g.R("b").Required(),
+		"C":      g.R("c").Required(),
+		"d":      g.R("d").Required(),
+		"E":      g.R("e").Required(),
+		"secret": g.R("secret").Min(3).AllowUnexported().SpecificMessages(galidator.Messages{"min": "$field is too short"}),
+	})
+	scenarios := []scenario{
+		{
+			name:      "pass-tags",
+			validator: g.Validator(unexportedTest{}),
+			in:        unexportedTest{A: "a", C: "c", E: "e", secret: "secret"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-tags",
+			validator: g.Validator(unexportedTest{}),
+			in:        unexportedTest{b: "b", d: "d", secret: "s"},
+			panic:     false,
+			expected:  map[string][]string{"a": {"required"}, "c": {"required"}, "e": {"required"}, "secret": {"secret is too short"}},
+		},
+		{
+			name:      "pass-complex",
+			validator: complexValidator,
+			in:        unexportedTest{A: "a", C: "c", E: "e", secret: "secret"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-complex",
+			validator: complexValidator,
+			in:        &unexportedTest{secret: "s"},
+			panic:     false,
+			expected:  map[string][]string{"a": {"required"}, "c": {"required"}, "e": {"required"}, "secret": {"secret is too short"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			// Map iteration order is random, so validate several times to visit fields in different orders
+			for i := 0; i < 20; i++ {
+				output := s.validator.Validate(context.TODO(), s.in)
+				if !check(t, s.expected, output) {
+					break
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"sort"
 	"testing"
 
 	"github.com/golodash/galidator/v2"
@@ -42,3 +43,23 @@ func check(t *testing.T, expected, output interface{}) bool {
 	}
 	return true
 }
+
+// Sorts error messages and replaces empty maps with nil, so outputs with different orders of messages can get compared
+func normalizeErrors(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		output := map[string]interface{}{}
+		for key, value := range v {
+			output[key] = normalizeErrors(value)
+		}
+		return output
+	case []string:
+		output := append([]string{}, v...)
+		sort.Strings(output)
+		return output
+	}
+	return input
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+type validateFieldAddress struct {
+	City string `json:"city" g:"required" required:"$field is required"`
+	Zip  string `json:"zip" g:"len=5" len:"$field must be 5 characters long"`
+}
+
+type validateFieldUser struct {
+	Username        string                 `json:"username" g:"required,len_range=3&16" required:"$field is required" len_range:"$field length is invalid"`
+	Password        string                 `json:"password" g:"required" required:"$field is required"`
+	ConfirmPassword string                 `json:"confirm_password" g:"when_exist_one=Password" when_exist_one:"$field is required when password exists"`
+	Tags            []string               `json:"tags" g:"c.len_range=2&8" c.len_range:"$value length is invalid"`
+	Addresses       []validateFieldAddress `json:"addresses"`
+	Home            *validateFieldAddress  `json:"home"`
+}
+
+func TestValidateField(t *testing.T) {
+	v := g.Validator(validateFieldUser{})
+	root := validateFieldUser{Password: "secret", Addresses: []validateFieldAddress{{City: "Tehran"}}}
+
+	scenarios := []struct {
+		scenario
+		path string
+		root interface{}
+	}{
+		{
+			scenario: scenario{name: "pass", validator: v, in: "username", panic: false, expected: nil},
+			path:     "username",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "fail", validator: v, in: "us", panic: false, expected: []string{"username length is invalid"}},
+			path:     "username",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "fail-go-name", validator: v, in: "", panic: false, expected: []string{"password is required"}},
+			path:     "Password",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "fail-requires", validator: v, in: "", panic: false, expected: []string{"confirm_password is required when password exists"}},
+			path:     "confirm_password",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "pass-requires", validator: v, in: "", panic: false, expected: nil},
+			path:     "confirm_password",
+			root:     &validateFieldUser{},
+		},
+		{
+			scenario: scenario{name: "fail-children", validator: v, in: "a", panic: false, expected: []string{"a length is invalid"}},
+			path:     "tags.3",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "fail-children-deep", validator: v, in: "123", panic: false, expected: []string{"zip must be 5 characters long"}},
+			path:     "addresses.0.zip",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "fail-children-deep-out-of-range", validator: v, in: "", panic: false, expected: []string{"city is required"}},
+			path:     "addresses.4.city",
+			root:     nil,
+		},
+		{
+			scenario: scenario{name: "fail-deep", validator: v, in: "", panic: false, expected: []string{"city is required"}},
+			path:     "home.city",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "error-unknown", validator: v, in: "", panic: false, expected: &galidator.PathError{Path: "home.country", Segment: "country", Reason: "has no rules"}},
+			path:     "home.country",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "error-not-index", validator: v, in: "", panic: false, expected: &galidator.PathError{Path: "tags.first", Segment: "first", Reason: "is not an index"}},
+			path:     "tags.first",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "error-negative-index", validator: v, in: "", panic: false, expected: &galidator.PathError{Path: "addresses.-1.city", Segment: "-1", Reason: "is not an index"}},
+			path:     "addresses.-1.city",
+			root:     root,
+		},
+		{
+			scenario: scenario{name: "error-empty", validator: v, in: "", panic: false, expected: &galidator.PathError{Path: "", Segment: "", Reason: "has no rules"}},
+			path:     "",
+			root:     root,
+		},
+		{
+			scenario: scenario{
+				name: "fail-complex",
+				validator: g.ComplexValidator(galidator.Rules{
+					"items": g.R().Children(g.R().Complex(galidator.Rules{
+						"count": g.R().Min(1).SpecificMessages(galidator.Messages{"min": "$field must be at least $min"}),
+					})),
+				}),
+				in:       0,
+				panic:    false,
+				expected: []string{"count must be at least 1"},
+			},
+			path: "items.0.count",
+			root: map[string]interface{}{"items": []interface{}{map[string]interface{}{"count": 0}}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			output, err := s.validator.ValidateField(context.TODO(), s.path, s.in, s.root)
+			if expected, ok := s.expected.(*galidator.PathError); ok {
+				pathErr, _ := err.(*galidator.PathError)
+				check(t, true, galidator.IsPathError(err) && *expected == *pathErr && output == nil)
+				check(t, expected.Error(), err.Error())
+				return
+			}
+			check(t, true, err == nil)
+			check(t, s.expected, output)
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		output, err := v.ValidateField(ctx, "username", "ab", root)
+		check(t, true, galidator.IsCanceled(err) && output == nil)
+	})
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golodash/galidator/v2"
+)
+
+func TestWhen(t *testing.T) {
+	v := g.ComplexValidator(galidator.Rules{
+		"country": g.R().String(),
+		"zip": g.R().String().
+			When(galidator.FieldEquals("country", "US")).
+			Then(g.R().Required().Regex(`^\d{5}$`).SpecificMessages(galidator.Messages{"regex": "$field must be 5 digits ($branch)", "required": "$field is required ($branch)"})).
+			Otherwise(g.R().LenRange(2, 10).SpecificMessages(galidator.Messages{"len_range": "$field must be $from to $to characters ($branch)"})),
+	})
+	requiredValidator := g.ComplexValidator(galidator.Rules{
+		"country": g.R().String(),
+		"state":   g.R().When(galidator.FieldEquals("country", "US")).Then(g.R().Required().SpecificMessages(galidator.Messages{"required": "$field is required ($branch)"})),
+	})
+	nestedValidator := g.ComplexValidator(galidator.Rules{
+		"country": g.R().String(),
+		"address": g.R().When(galidator.FieldEquals("country", "US")).Then(g.R().Complex(galidator.Rules{
+			"zip": g.R().Required().SpecificMessages(galidator.Messages{"required": "$field is required"}),
+		})),
+		"phones": g.R().When(galidator.FieldEquals("country", "US")).Then(g.R().Slice().Children(g.R().Len(10).SpecificMessages(galidator.Messages{"len": "$value is invalid"}))),
+	})
+	rootChildrenValidator := g.Validator(g.R().When(func(ctx context.Context, parent interface{}) bool {
+		return true
+	}).Then(g.R().Children(g.R().Min(2).SpecificMessages(galidator.Messages{"min": "$value is short"}))))
+	predicateValidator := g.Validator(g.R().Int().When(func(ctx context.Context, parent interface{}) bool {
+		return parent.(int) > 100
+	}).Then(g.R().Max(1000)))
+
+	scenarios := []scenario{
+		{
+			name:      "pass-then",
+			validator: v,
+			in:        map[string]interface{}{"country": "US", "zip": "12345"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-then",
+			validator: v,
+			in:        map[string]interface{}{"country": "US", "zip": "1234a"},
+			panic:     false,
+			expected:  map[string][]string{"zip": {"zip must be 5 digits (then)"}},
+		},
+		{
+			name:      "pass-then-required",
+			validator: requiredValidator,
+			in:        map[string]interface{}{"country": "IR", "state": ""},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-then-required",
+			validator: requiredValidator,
+			in:        map[string]interface{}{"country": "US", "state": ""},
+			panic:     false,
+			expected:  map[string][]string{"state": {"state is required (then)"}},
+		},
+		{
+			name:      "pass-otherwise",
+			validator: v,
+			in:        map[string]interface{}{"country": "IR", "zip": "1234a"},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "pass-otherwise-empty",
+			validator: v,
+			in:        map[string]interface{}{"country": "IR", "zip": ""},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-otherwise",
+			validator: v,
+			in:        map[string]interface{}{"country": "IR", "zip": "1234567890a"},
+			panic:     false,
+			expected:  map[string][]string{"zip": {"zip must be 2 to 10 characters (otherwise)"}},
+		},
+		{
+			name:      "pass-predicate",
+			validator: predicateValidator,
+			in:        50,
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-predicate",
+			validator: predicateValidator,
+			in:        1001,
+			panic:     false,
+			expected:  []string{"'s length must be lower equal to 1000"},
+		},
+		{
+			name:      "pass-nested-otherwise",
+			validator: nestedValidator,
+			in:        map[string]interface{}{"country": "IR", "address": map[string]interface{}{}, "phones": []string{"1"}},
+			panic:     false,
+			expected:  nil,
+		},
+		{
+			name:      "fail-nested-then",
+			validator: nestedValidator,
+			in:        map[string]interface{}{"country": "US", "address": map[string]interface{}{"zip": ""}, "phones": []string{"1"}},
+			panic:     false,
+			expected: map[string]interface{}{
+				"address": map[string]interface{}{"zip": []string{"zip is required"}},
+				"phones":  map[string]interface{}{"0": []string{"1 is invalid"}},
+			},
+		},
+		{
+			name:      "fail-root-children-then",
+			validator: rootChildrenValidator,
+			in:        []string{"ab", "a"},
+			panic:     false,
+			expected:  map[string]interface{}{"1": []string{"a is short"}},
+		},
+		{
+			name:      "panic-then-without-when",
+			validator: nil,
+			in:        nil,
+			panic:     true,
+			expected:  nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			defer deferTestCases(t, s.panic, s.expected)
+
+			if s.validator == nil {
+				g.R().Then(g.R())
+			}
+			output := s.validator.Validate(context.TODO(), s.in)
+			check(t, s.expected, output)
+		})
+	}
+
+	t.Run("then-does-not-change-passed-ruleSet", func(t *testing.T) {
+		shared := g.R().Min(3)
+		v := g.Validator(g.R().When(func(ctx context.Context, parent interface{}) bool { return true }).Then(shared).Then(g.R().Max(5)))
+		check(t, []string{"'s length must be higher equal to 3"}, v.Validate(context.TODO(), "ab"))
+		check(t, nil, g.Validator(shared).Validate(context.TODO(), "abcdefg"))
+	})
+}
@@ -3,22 +3,21 @@ package galidator
 import (
 	"context"
 	"fmt"
-	"math"
 	"reflect"
 	"strconv"
 	"strings"
 
+	"github.com/golodash/galidator/v2/internal/shared"
 	gStrings "github.com/golodash/godash/strings"
 )
 
-// Determines the precision of a float number for print
-func determinePrecision(number float64) string {
-	for i := 0; ; i++ {
-		ten := math.Pow10(i)
-		if math.Floor(ten*number) == ten*number {
-			return fmt.Sprint(i)
-		}
+// Returns a copy of passed option as a map
+func copyOption(input option) map[string]string {
+	output := map[string]string{}
+	for key, value := range input {
+		output[key] = value
 	}
+	return output
 }
 
 // Returns true if input is nil
@@ -26,13 +25,13 @@ func isNil(input interface{}) bool {
 	return !reflect.ValueOf(input).IsValid() || input == nil || (reflect.TypeOf(input).Kind() == reflect.Ptr && reflect.ValueOf(input).IsNil())
 }
 
-// Returns true if input is map or slice and has 0 elements
+// Returns true if input is map, slice or array and has 0 elements
 //
-// Returns false if input is not map or slice
+// Returns false if input is not map, slice or array
 func hasZeroItems(input interface{}) bool {
 	inputValue := reflect.ValueOf(input)
 	switch inputValue.Kind() {
-	case reflect.Slice, reflect.Map:
+	case reflect.Slice, reflect.Array, reflect.Map:
 		return inputValue.Len() == 0
 	default:
 		return false
@@ -76,33 +75,190 @@ func getValues(all interface{}, fields ...string) []interface{} {
 	return fieldsValues
 }
 
+// Returns the name that is defined for passed field in its json tag
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+// Returns value of passed key in passed struct or map, pointers get dereferenced,
+// fields of structs are found by their name or the name defined in their json tag
+//
+// Returns nil if key does not exist
+func valueOfKey(inputValue reflect.Value, key string) interface{} {
+	var value reflect.Value
+	switch inputValue.Kind() {
+	case reflect.Map:
+		if keyType := inputValue.Type().Key(); keyType.Kind() == reflect.String {
+			value = inputValue.MapIndex(reflect.ValueOf(key).Convert(keyType))
+		}
+	case reflect.Struct:
+		if field, _, found := fieldByName(inputValue, key); found {
+			value = field
+		} else {
+			for _, field := range promotedFields(inputValue.Type()) {
+				if jsonName(field) == key {
+					value, _, _ = fieldByName(inputValue, field.Name)
+					break
+				}
+			}
+		}
+	}
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return nil
+	}
+	return value.Interface()
+}
+
+// Returns true if passed field is an embedded struct whose fields have to get promoted into its parent, like encoding/json does
+//
+// Embedded structs that have a name in their json tag or have `nested` in their g or galidator tag stay as a nested field
+func isFlattenedEmbedded(field reflect.StructField) bool {
+	if !field.Anonymous {
+		return false
+	}
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct {
+		return false
+	}
+	if name := jsonName(field); name != "" && name != "-" {
+		return false
+	}
+	for _, fullTag := range []string{field.Tag.Get("g"), field.Tag.Get("galidator")} {
+		for _, filter := range strings.Split(fullTag, ",") {
+			if gStrings.PascalCase(strings.TrimSpace(filter)) == "Nested" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns fields of passed struct type with fields of embedded structs promoted to the top level
+//
+// Promotion follows Go's shadowing rules, a shallower field hides deeper ones with the same
+// name and fields with the same name in the same depth hide each other
+func promotedFields(structType reflect.Type) []reflect.StructField {
+	fields := []reflect.StructField{}
+	for _, field := range reflect.VisibleFields(structType) {
+		if isFlattenedEmbedded(field) {
+			continue
+		}
+		promoted := true
+		for i := 1; i < len(field.Index); i++ {
+			if !isFlattenedEmbedded(structType.FieldByIndex(field.Index[:i])) {
+				promoted = false
+				break
+			}
+		}
+		if promoted {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// Returns value and type of the passed field name in passed struct value
+//
+// If field is promoted through a nil embedded pointer, returned value is not valid
+func fieldByName(structValue reflect.Value, name string) (reflect.Value, reflect.StructField, bool) {
+	field, found := structValue.Type().FieldByName(name)
+	if !found {
+		return reflect.Value{}, field, false
+	}
+
+	return fieldByIndex(structValue, field.Index), field, true
+}
+
 // Returns a list of keys for requires which determine not required and a bool which determines if we need to validate or not
-func determineRequires(all interface{}, input interface{}, requires requires) (map[string]interface{}, bool) {
+//
+// Requires which are not in active validation groups of ctx get ignored, field is the plan of the field or nil
+func determineRequires(ctx context.Context, all interface{}, input interface{}, r ruleSet, field *fieldPlan) (map[string]interface{}, bool) {
 	output := map[string]interface{}{}
-	if len(requires) == 0 {
+	active := 0
+	for key, req := range r.getRequires() {
+		if !r.isActive(ctx, key) {
+			continue
+		}
+		active++
+		if !req.check(requireValues(all, key, req, field), input) {
+			output[key] = 1
+		}
+	}
+	if active == 0 {
 		return output, false
 	}
-	for key, req := range requires {
-		if !req(all)(input) {
-			output[key] = 1
+
+	return output, len(output) == active
+}
+
+// Splits validation groups from a filter of a tag, like: `required@create|update`
+func splitGroups(filter string) (string, []string) {
+	index := strings.LastIndex(filter, "@")
+	if index == -1 || !shared.GroupsPattern.MatchString(filter[index+1:]) {
+		return filter, nil
+	}
+	return filter[:index], strings.Split(filter[index+1:], "|")
+}
+
+// Limits rule with passed funcName to passed validation groups
+func addGroups(r ruleSet, funcName string, groups []string) {
+	splits := strings.SplitN(funcName, ".", 2)
+	if firstElementSnake := gStrings.SnakeCase(splits[0]); len(splits) > 1 && (firstElementSnake == "c" || firstElementSnake == "child") {
+		addGroups(r.getChildrenValidator().getRule(), splits[1], groups)
+		return
+	} else if len(splits) > 1 && (firstElementSnake == "then" || firstElementSnake == "otherwise") {
+		addGroups(r.getBranch(firstElementSnake), splits[1], groups)
+		return
+	}
+	if len(groups) != 0 {
+		r.Groups(gStrings.SnakeCase(funcName), groups...)
+	}
+}
+
+// Returns true if at least one of passed branches is required
+func branchesAreRequired(ctx context.Context, branches []branch) bool {
+	for _, b := range branches {
+		if b.rule.isRequiredIn(ctx) {
+			return true
 		}
 	}
+	return false
+}
 
-	return output, len(output) == len(requires)
+// Returns ruleSets of passed branches
+func branchRuleSets(branches []branch) []ruleSet {
+	output := make([]ruleSet, len(branches))
+	for i, b := range branches {
+		output[i] = b.rule
+	}
+	return output
 }
 
-// Passes messages to other validators
-func deepPassMessages(v Validator, messages *Messages) {
+// Passes messages, message functions, messages of custom rules and locale of error messages to other validators
+func deepPassMessages(v Validator, messages *Messages, messageFuncs MessageFuncs, customMessages ruleMessages, locale string) {
 	v.setMessages(messages)
+	v.setMessageFuncs(messageFuncs)
+	v.setRuleMessages(customMessages)
+	v.setLocale(locale)
 	r := v.getRule()
 	if r != nil {
 		if v1 := r.getChildrenValidator(); v1 != nil {
-			deepPassMessages(v1, messages)
+			deepPassMessages(v1, messages, messageFuncs, customMessages, locale)
 		}
 		if v2 := r.getDeepValidator(); v2 != nil {
-			deepPassMessages(v2, messages)
+			deepPassMessages(v2, messages, messageFuncs, customMessages, locale)
 		}
 	}
+	for _, variant := range v.getVariants() {
+		deepPassMessages(variant, messages, messageFuncs, customMessages, locale)
+	}
 	rs := v.getRules()
 	if rs == nil {
 		return
@@ -110,21 +266,78 @@ func deepPassMessages(v Validator, messages *Messages) {
 	for _, r := range rs {
 		if r != nil {
 			if v1 := r.getChildrenValidator(); v1 != nil {
-				deepPassMessages(v1, messages)
+				deepPassMessages(v1, messages, messageFuncs, customMessages, locale)
 			}
 			if v2 := r.getDeepValidator(); v2 != nil {
-				deepPassMessages(v2, messages)
+				deepPassMessages(v2, messages, messageFuncs, customMessages, locale)
 			}
 		}
 	}
 }
 
+// Makes ruleSets of passed validator and its nested validators sensitive if sensitive is true or their parent ruleSet is sensitive
+//
+// Validators which are registered by RegisterType are shared, so they keep their own settings
+func deepPassSensitive(v Validator, sensitive bool) {
+	ruleSets := []ruleSet{}
+	if r := v.getRule(); r != nil {
+		ruleSets = append(ruleSets, r)
+	}
+	for _, r := range v.getRules() {
+		if r != nil {
+			ruleSets = append(ruleSets, r)
+		}
+	}
+	for _, r := range ruleSets {
+		if sensitive {
+			r.Sensitive()
+		}
+		r.passSensitive()
+	}
+	for _, variant := range v.getVariants() {
+		deepPassSensitive(variant, sensitive)
+	}
+}
+
+// Returns errors which happened while building ruleSets of passed validator and its nested validators
+func collectErrors(v Validator) []error {
+	output := []error{}
+	r := v.getRule()
+	if r != nil {
+		output = append(output, collectRuleSetErrors(r)...)
+	}
+	for _, variant := range v.getVariants() {
+		output = append(output, collectErrors(variant)...)
+	}
+	for _, r := range v.getRules() {
+		if r != nil {
+			output = append(output, collectRuleSetErrors(r)...)
+		}
+	}
+	return output
+}
+
+// Returns errors of passed ruleSet and its deep and children validators
+func collectRuleSetErrors(r ruleSet) []error {
+	output := r.getErrors()
+	if v1 := r.getChildrenValidator(); v1 != nil {
+		output = append(output, collectErrors(v1)...)
+	}
+	if v2 := r.getDeepValidator(); v2 != nil {
+		output = append(output, collectErrors(v2)...)
+	}
+	return output
+}
+
 // Adds one specific message to passed ruleSet if message is not a empty string
 func addSpecificMessage(r ruleSet, funcName, message string) {
 	splits := strings.SplitN(funcName, ".", 2)
 	if firstElementSnake := gStrings.SnakeCase(splits[0]); len(splits) > 1 && (firstElementSnake == "c" || firstElementSnake == "child") {
 		addSpecificMessage(r.getChildrenValidator().getRule(), splits[1], message)
 		return
+	} else if len(splits) > 1 && (firstElementSnake == "then" || firstElementSnake == "otherwise") {
+		addSpecificMessage(r.getBranch(firstElementSnake), splits[1], message)
+		return
 	}
 	funcName = gStrings.SnakeCase(funcName)
 	if message != "" {
@@ -147,6 +360,16 @@ func applyRules(r ruleSet, tag []string, o *generatorS, orXor bool) (normalFuncN
 			applyRules(r.getChildrenValidator().getRule(), []string{splits[1]}, o, orXor)
 		}
 		return normalFuncName
+	} else if len(splits) > 1 && (firstElementSnake == "then" || firstElementSnake == "otherwise") {
+		if r.getBranch(firstElementSnake) == nil {
+			if firstElementSnake == "then" {
+				r.Then(o.R())
+			} else {
+				r.Otherwise(o.R())
+			}
+		}
+		applyRules(r.getBranch(firstElementSnake), append([]string{splits[1]}, tag[1:]...), o, orXor)
+		return normalFuncName
 	} else if len(splits) == 1 {
 		funcName = gStrings.PascalCase(normalFuncName)
 	} else {
@@ -202,8 +425,8 @@ func applyRules(r ruleSet, tag []string, o *generatorS, orXor bool) (normalFuncN
 	case "Email":
 		r.Email()
 	case "Regex":
-		if len(parameters) > 1 {
-			r.Regex(parameters[0])
+		if len(tag) == 2 {
+			r.Regex(tag[1])
 		}
 	case "Phone":
 		r.Phone()
@@ -263,13 +486,32 @@ func applyRules(r ruleSet, tag []string, o *generatorS, orXor bool) (normalFuncN
 		r.WhenNotExistOne(parameters...)
 	case "WhenNotExistAll":
 		r.WhenNotExistAll(parameters...)
+	case "When":
+		if len(parameters) < 2 {
+			panic("when rule needs a field and at least one value, like: when=Country&US")
+		}
+		values := []interface{}{}
+		for _, item := range parameters[1:] {
+			values = append(values, item)
+		}
+		r.When(FieldEquals(parameters[0], values...))
 	case "String":
 		r.String()
+	case "AllowUnexported":
+		r.AllowUnexported()
+	case "Sensitive":
+		r.Sensitive()
+	case "Nested":
+		// Only changes how an embedded struct is treated, see `promotedFields`
 	case "Children", "Custom", "Complex", "Type":
 		panic(fmt.Sprintf("take a look at documentations, %s rule does not work in tags like this", funcName))
 	default:
+		if rule, ok := o.getCustomRule(normalFuncName); ok {
+			r.Rule(normalFuncName, parameters...)
+			return rule.Key
+		}
 		if normalFuncName != "" {
-			if function, ok := o.customValidators[normalFuncName]; ok {
+			if function := o.GetCustomValidator(normalFuncName); function != nil {
 				r.Custom(Validators{
 					normalFuncName: function,
 				})
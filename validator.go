@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"unsafe"
 
 	playgroundValidator "github.com/go-playground/validator/v10"
 	gStrings "github.com/golodash/godash/strings"
@@ -20,6 +22,20 @@ type (
 	// To specify errors for rules
 	Messages map[string]string
 
+	// Returns error message of a failed rule, ruleKey is the key of the rule and options are its options like `min`
+	//
+	// Returned message gets translated and formatted like messages of Messages, so it is a template too,
+	// runtime data in it has to be escaped by EscapeMessage
+	//
+	// ctx is the context which is passed to validation, DecryptErrors has no context and passes context.Background()
+	MessageFunc func(ctx context.Context, fieldName string, value interface{}, ruleKey string, options map[string]string) string
+
+	// To specify errors for rules by functions
+	MessageFuncs map[string]MessageFunc
+
+	// To define rules of a discriminated union with discriminator values as keys
+	Variants map[string]Rules
+
 	Translator func(string) string
 
 	// A struct to implement Validator interface
@@ -30,17 +46,93 @@ type (
 		rules Rules
 		// Stores custom error messages sent by user
 		messages *Messages
+		// Stores custom message functions sent by user
+		messageFuncs MessageFuncs
+		// Locale of catalog which error messages come from if there is no custom message for a rule
+		locale string
+		// Error messages of custom rules of the generator, used if there is no other message for a rule
+		ruleMessages ruleMessages
+		// Checks discriminator field of a discriminated union, its name is the discriminator key
+		discriminatorRule ruleSet
+		// Validators of a discriminated union with discriminator values as keys
+		variants map[string]Validator
+		// Compiled plans of struct types with reflect.Type as keys
+		plans sync.Map
+		// Used to determine async only once
+		asyncOnce sync.Once
+		// True if at least one of ruleSets has I/O-bound rules
+		async bool
+		// Used to sort keys of rules only once
+		keysOnce sync.Once
+		// Sorted keys of rules
+		keys []string
 	}
 
 	// Used just in decryptErrors function
 	sliceValidationError []error
 
+	// A failed rule, validation returns them instead of error messages if keys of rules are needed, like for Problem method
+	failure struct {
+		// Key of the rule, like `min`
+		rule string
+		// Formatted error message of the rule
+		message string
+	}
+
+	// Returned by ValidateField as error when passed path does not point to a field with rules
+	PathError struct {
+		// Passed path
+		Path string
+		// Segment of the path which does not point to a field with rules
+		Segment string
+		// Explains why the segment is not valid
+		Reason string
+	}
+
 	// Validator interface
 	Validator interface {
 		// Validates passed data and returns a map of possible validation errors happened on every field with failed validation.
 		//
 		// If no errors found, output will be nil
+		//
+		// A nil input (or nil pointer) of a struct or map validator fails with `non_nil` message
 		Validate(ctx context.Context, input interface{}, translator ...Translator) interface{}
+		// Validates passed data like Validate method, but only fields which are present in input get validated
+		//
+		// Absent fields are ignored entirely, even if they are required.
+		//
+		// A field is absent if its key is missing in a map or its value is a nil pointer in a struct.
+		// If keys are captured with `WithPresentKeys`, a field is absent if its name is not in them.
+		ValidatePartial(ctx context.Context, input interface{}, translator ...Translator) interface{}
+		// Validates just one field, addressed by a dotted path like `addresses.0.zip`, and returns its errors
+		//
+		// value is the value of the field and root is the whole data which the field belongs to,
+		// cross-field rules and requires check other fields in root.
+		//
+		// Error is a *PathError if path does not point to a field with rules, like an unknown name or an index which is not a number,
+		// and a *CanceledError if ctx is done before validation finishes, output is nil then
+		ValidateField(ctx context.Context, path string, value interface{}, root interface{}, translator ...Translator) (interface{}, error)
+		// Validates records of passed slice, array or channel concurrently and returns their outputs sorted by their index
+		//
+		// Stops early if ctx is done or ErrorBudget of options is reached, records which did not get validated are counted as skipped
+		ValidateBatch(ctx context.Context, inputs interface{}, options ...BatchOptions) *BatchResult
+		// Resolves fields of types of passed samples and caches them, so the first validation of these types
+		// does not need to do it
+		//
+		// Types get compiled on their first validation anyway, types of nested structs and slice elements get compiled too
+		Compile(samples ...interface{}) Validator
+		// Validates passed data like Validate method while passed validation groups are active
+		//
+		// Rules which are limited to some groups only apply if at least one of their groups is passed
+		ValidateGroups(ctx context.Context, input interface{}, groups []string, translator ...Translator) interface{}
+		// Validates passed data like Validate method and returns its errors as a Problem Details document (RFC 9457)
+		//
+		// Output is nil if input is valid, error is a *CanceledError if ctx is done before validation finishes
+		Problem(ctx context.Context, input interface{}, options ...ProblemOptions) (*Problem, error)
+		// Returns a JSON Schema of current validator, discriminated unions are exported as `oneOf`
+		//
+		// Rules which have no JSON Schema equivalent, like custom rules, conditions and cross-field rules, are left out
+		JSONSchema() map[string]interface{}
 		// Decrypts errors returned from gin's Bind process and returns proper error messages
 		//
 		// If returnUnmarshalErrorContext is true (default is true), if an error happened when
@@ -53,18 +145,50 @@ type (
 		SetDefaultOnNil(input interface{}, defaultValue interface{})
 		// Sets passed default values if value field is zero
 		SetDefault(input interface{}, defaultValue interface{})
-		// Returns ruleSet of an input
+		// Returns a copy of ruleSet of an input, changing it does not change the validator
 		GetStructRule(input string) ruleSet
-		// Returns the ruleSet of current validator
+		// Returns a copy of the ruleSet of current validator, changing it does not change the validator
 		GetRule() ruleSet
+		// Returns name of discriminator field if current validator is a discriminated union
+		GetDiscriminator() string
+		// Returns copies of validators of variants with discriminator values as keys if current validator is a discriminated union,
+		// changing them does not change the validator
+		GetVariants() map[string]Validator
+		// Returns a deep copy of current validator, changing the copy has no effect on current validator and vice versa
+		//
+		// Types which are compiled in current validator get compiled in the copy too
+		Clone() Validator
+		// Returns a copy of current validator which uses passed message functions for error messages of its rules
+		//
+		// A function wins over a message of the same rule, SpecificMessages and SpecificMessageFuncs of ruleSets still win over functions
+		WithMessageFuncs(messageFuncs MessageFuncs) Validator
 		// Returns Rules
 		getRules() Rules
 		// Returns rule
 		getRule() ruleSet
+		// Returns variants
+		getVariants() map[string]Validator
+		// Returns true if ruleSets of current validator or its nested validators have I/O-bound rules
+		hasAsyncRules() bool
+		// Validates input like Validate method with passed translator, error messages of fields come as []failure
+		// with keys of their rules if withRules is true
+		validate(ctx context.Context, input interface{}, t Translator, withRules bool) interface{}
 		// Replaces passed messages with existing one
 		setMessages(messages *Messages)
 		// Returns messages
 		getMessages() *Messages
+		// Replaces passed message functions with existing ones
+		setMessageFuncs(messageFuncs MessageFuncs)
+		// Returns message functions
+		getMessageFuncs() MessageFuncs
+		// Replaces passed messages of custom rules with existing ones
+		setRuleMessages(messages ruleMessages)
+		// Returns messages of custom rules
+		getRuleMessages() ruleMessages
+		// Replaces passed locale with existing one
+		setLocale(locale string)
+		// Returns locale
+		getLocale() string
 	}
 )
 
@@ -73,27 +197,95 @@ const (
 	UnmarshalError = "unmarshal error"
 )
 
+// Used to report nil values that are passed to a struct or map validator
+var nilRuleSet = (&ruleSetS{validators: Validators{}, requires: requires{}, options: options{}}).NonNil()
+
 func (err sliceValidationError) Error() string {
 	return "error"
 }
 
-func getFormattedErrorMessage(message string, fieldName string, value interface{}, options option, translator ...Translator) string {
+// Renders passed message template with passed options, `$field` and `$value` placeholders
+//
+// Besides placeholders, templates support `{name, number}` to format a number for passed locale,
+// `{name, plural, =0 {...} one {...} other {...}}` to choose text by plural form of a number (`#` is the number in it),
+// `{name, select, value {...} other {...}}` to choose text by a value and `$$`, `${`, `$}` and `$#` to escape `$`, `{`, `}` and `#`
+func getFormattedErrorMessage(message string, fieldName string, value interface{}, options option, locale string, translator ...Translator) string {
+	if !strings.ContainsAny(message, "${") {
+		return message
+	}
 	var t Translator = nil
 	if len(translator) != 0 {
 		t = translator[0]
 	}
+	params := option{}
 	for key, value := range options {
-		message = strings.ReplaceAll(message, "$"+key, value)
+		params[key] = value
 	}
 	newValue := fmt.Sprint(value)
+	if value != RedactedValue {
+		newValue = truncateValue(newValue)
+	}
 	if t != nil {
 		newValue = t(newValue)
 	}
-	return strings.ReplaceAll(strings.ReplaceAll(message, "$field", fieldName), "$value", newValue)
+	params["field"] = fieldName
+	params["value"] = newValue
+
+	renderer := &templateRenderer{params: params, locale: locale}
+	return renderer.render(message)
+}
+
+// Returns the name which replaces `$field` in error messages of passed ruleSet
+//
+// Label of ruleSet gets translated by catalog of passed locale and then by passed translator, fieldName is returned if ruleSet has no label
+func fieldLabel(ruleSet ruleSet, fieldName string, locale string, t Translator) string {
+	label := ruleSet.getLabel()
+	if label == "" {
+		return fieldName
+	}
+	return translateLabel(label, locale, t)
+}
+
+// Returns passed label translated by catalog of passed locale and then by passed translator
+func translateLabel(label string, locale string, t Translator) string {
+	if translated, ok := catalogMessage(locale, "label."+label); ok {
+		label = translated
+	}
+	if t != nil {
+		label = t(label)
+	}
+	return label
+}
+
+// Returns message function associated with passed ruleKey, returns nil if a message string wins or there is no function
+func getMessageFunc(ruleKey string, messageFuncs MessageFuncs, specificMessageFuncs MessageFuncs, specificMessage Messages) MessageFunc {
+	specificKey := gStrings.SnakeCase(ruleKey)
+	if out, ok := specificMessageFuncs[specificKey]; ok {
+		return out
+	}
+	if _, ok := specificMessage[specificKey]; ok {
+		return nil
+	}
+	return messageFuncs[ruleKey]
+}
+
+// Returns error message of failed rule with passed key, message funcs and messages are searched
+// like getMessageFunc and getRawErrorMessage do and the message gets translated and formatted
+func errorMessage(ctx context.Context, ruleKey string, fieldName string, value interface{}, opt option, messages Messages, messageFuncs MessageFuncs, specificMessages Messages, specificMessageFuncs MessageFuncs, customMessages ruleMessages, locale string, t Translator) string {
+	var message string
+	if f := getMessageFunc(ruleKey, messageFuncs, specificMessageFuncs, specificMessages); f != nil {
+		message = f(ctx, fieldName, value, ruleKey, copyOption(opt))
+	} else {
+		message = getRawErrorMessage(ruleKey, messages, specificMessages, customMessages, locale)
+	}
+	if t != nil {
+		message = t(message)
+	}
+	return getFormattedErrorMessage(message, fieldName, value, opt, locale, t)
 }
 
 // Formats and returns error message associated with passed ruleKey
-func getRawErrorMessage(ruleKey string, messages Messages, specificMessage Messages, defaultErrorMessages map[string]string) string {
+func getRawErrorMessage(ruleKey string, messages Messages, specificMessage Messages, customMessages ruleMessages, locale string) string {
 	// Search for error message in specific messages for the rule
 	if out, ok := specificMessage[gStrings.SnakeCase(ruleKey)]; len(specificMessage) != 0 && ok {
 		return out
@@ -103,9 +295,12 @@ func getRawErrorMessage(ruleKey string, messages Messages, specificMessage Messa
 	if out, ok := messages[ruleKey]; ok {
 		return out
 	} else {
-		// If no error message found, search if there is a default error message for rule error
-		if defaultErrorMessage, ok := defaultErrorMessages[ruleKey]; ok {
+		// If no error message found, search if there is an error message for rule error in catalog of locale
+		if defaultErrorMessage, ok := catalogMessage(locale, ruleKey); ok {
 			return defaultErrorMessage
+		} else if customMessage, ok := customMessages.get(locale, ruleKey); ok {
+			// Messages of custom rules of the generator come last, so they do not replace messages of catalogs
+			return customMessage
 		} else {
 			// If no error message found, return that error message doesn't exist
 			return fmt.Sprintf("error happened but no error message exists on '%s' rule key", ruleKey)
@@ -114,228 +309,567 @@ func getRawErrorMessage(ruleKey string, messages Messages, specificMessage Messa
 }
 
 func (o *validatorS) GetRule() ruleSet {
-	return o.rule
+	if o.rule == nil {
+		return nil
+	}
+	return o.rule.Clone()
+}
+
+func (o *validatorS) GetDiscriminator() string {
+	if o.discriminatorRule == nil {
+		return ""
+	}
+	return o.discriminatorRule.getName()
+}
+
+func (o *validatorS) GetVariants() map[string]Validator {
+	if o.variants == nil {
+		return nil
+	}
+	output := make(map[string]Validator, len(o.variants))
+	for key, variant := range o.variants {
+		output[key] = variant.Clone()
+	}
+	return output
 }
 
 func (o *validatorS) GetStructRule(input string) ruleSet {
 	if rule, ok := o.rules[input]; ok {
-		return rule
+		return rule.Clone()
 	}
 	return nil
 }
 
 func (o *validatorS) Validate(ctx context.Context, input interface{}, translator ...Translator) interface{} {
-	for reflect.ValueOf(input).Kind() == reflect.Ptr {
-		input = reflect.ValueOf(input).Elem().Interface()
-	}
 	var t Translator = nil
 	if len(translator) != 0 {
 		t = translator[0]
+	} else {
+		t = TranslatorFromContext(ctx)
+	}
+	return o.validate(ctx, input, t, false)
+}
+
+func (o *validatorS) validate(ctx context.Context, input interface{}, t Translator, withRules bool) interface{} {
+	for reflect.ValueOf(input).Kind() == reflect.Ptr {
+		if reflect.ValueOf(input).IsNil() {
+			input = nil
+			break
+		}
+		input = reflect.ValueOf(input).Elem().Interface()
+	}
+	if err := checkContext(ctx); err != nil {
+		return err
 	}
 
 	output := map[string]interface{}{}
 	inputValue := reflect.ValueOf(input)
 
-	validate := func(ruleSet ruleSet, onKeyInput interface{}, fieldName string) []string {
-		for reflect.ValueOf(onKeyInput).IsValid() && reflect.TypeOf(onKeyInput).Kind() == reflect.Ptr {
-			onKeyInputValue := reflect.ValueOf(onKeyInput).Elem()
-			if onKeyInputValue.IsValid() {
-				onKeyInput = onKeyInputValue.Interface()
-			} else {
-				onKeyInput = nil
-			}
+	if o.variants != nil {
+		key := o.discriminatorRule.getName()
+		value := valueOfKey(inputValue, key)
+		errors := o.validateRuleSet(ctx, o.discriminatorRule, value, key, t, nil)
+		if err := checkContext(ctx); err != nil {
+			return err
 		}
-
-		halfOutput := []string{}
-		fails := ruleSet.validate(ctx, onKeyInput)
-		if len(fails) != 0 {
-			for _, failKey := range fails {
-				var m Messages = nil
-				var sm Messages = ruleSet.getSpecificMessages()
-				if o.messages != nil {
-					m = *o.messages
-				}
-				message := getRawErrorMessage(failKey, m, sm, defaultValidatorErrorMessages)
-				if t != nil {
-					message = t(message)
-				}
-				message = getFormattedErrorMessage(message, fieldName, onKeyInput, ruleSet.getOption(failKey), t)
-				halfOutput = append(halfOutput, message)
-			}
+		if len(errors) != 0 {
+			return map[string]interface{}{key: failuresOutput(errors, withRules)}
 		}
-
-		return halfOutput
+		return o.variants[fmt.Sprint(value)].validate(ctx, input, t, withRules)
 	}
 
 	if o.rules != nil {
 		switch inputValue.Kind() {
+		case reflect.Invalid:
+			// Like a nil element in a slice of pointers to structs
+			return failuresOutput(o.validateRuleSet(ctx, nilRuleSet, input, "", t, nil), withRules)
 		case reflect.Struct:
-			for fieldName, ruleSet := range o.rules {
-				valueOnKeyInput := inputValue.FieldByName(fieldName)
-				typeOnKeyInput, found := inputValue.Type().FieldByName(fieldName)
-				if ruleSet.getName() != "" {
-					fieldName = ruleSet.getName()
-				}
-
-				if !valueOnKeyInput.IsValid() {
-					valueOnKeyInput = inputValue.FieldByName(fieldName)
+			plan := o.getPlan(inputValue.Type())
+			if o.isConcurrent(ctx) {
+				// Fields can not create it on demand at the same time
+				addressableInput := addressableCopy(inputValue)
+				o.validateAll(ctx, output, len(plan.fields), func(i int) (string, interface{}) {
+					return plan.fields[i].name, o.validateStructField(ctx, input, inputValue, &addressableInput, &plan.fields[i], t, withRules)
+				})
+				break
+			}
+			// An addressable copy of input, used to read unexported fields
+			var addressableInput reflect.Value
+			for i := range plan.fields {
+				errors := o.validateStructField(ctx, input, inputValue, &addressableInput, &plan.fields[i], t, withRules)
+				if checkContext(ctx) != nil {
+					break
 				}
-				if !valueOnKeyInput.IsValid() {
-					panic(fmt.Sprintf("value on %s is not valid", fieldName))
+				if errors != nil {
+					output[plan.fields[i].name] = errors
 				}
-
-				// If not exported
-				if !found || typeOnKeyInput.PkgPath != "" {
+			}
+		case reflect.Map:
+			keys := o.ruleKeys()
+			if o.isConcurrent(ctx) {
+				o.validateAll(ctx, output, len(keys), func(i int) (string, interface{}) {
+					return o.validateMapKey(ctx, input, inputValue, keys[i], t, withRules)
+				})
+				break
+			}
+			for _, key := range keys {
+				fieldName, errors := o.validateMapKey(ctx, input, inputValue, key, t, withRules)
+				if checkContext(ctx) != nil {
 					break
 				}
-				value := valueOnKeyInput.Interface()
-				// Just continue if no requires are set and field is empty, nil or zero
-				requires, isRequired := determineRequires(input, value, ruleSet.getRequires())
-				if (!ruleSet.isRequired() && !isRequired) && isEmptyNilZero(value) {
-					continue
+				if errors != nil {
+					output[fieldName] = errors
 				}
+			}
+		default:
+			return []string{"invalid input"}
+		}
+		if err := checkContext(ctx); err != nil {
+			return err
+		}
+	} else if o.rule != nil {
+		// There is no parent, so conditions decide based on input itself
+		branches := o.rule.getBranches(ctx, input)
+		if !o.rule.isRequiredIn(ctx) && !branchesAreRequired(ctx, branches) && isEmptyNilZero(input) {
+			return nil
+		}
 
-				errors := validate(ruleSet, value, fieldName)
-				dels := []int{}
-				for i, key := range errors {
-					if _, ok := requires[key]; ok {
-						dels = append(dels, i)
-					}
-				}
-				j := 0
-				for _, key := range dels {
-					errors = append(errors[:key-j], errors[key+1-j:]...)
-					j++
+		errors := o.validateRuleSet(ctx, o.rule, input, o.rule.getName(), t, nil)
+		for _, b := range branches {
+			errors = append(errors, o.validateRuleSet(ctx, b.rule, input, o.rule.getName(), t, option{"branch": b.name})...)
+		}
+		if err := checkContext(ctx); err != nil {
+			return err
+		}
+		if len(errors) != 0 {
+			return failuresOutput(errors, withRules)
+		}
+
+		// Nested validators of applied branches work like nested validators of the rule itself
+		for _, r := range append([]ruleSet{o.rule}, branchRuleSets(branches)...) {
+			if r.isDynamic() && !isNil(input) {
+				errors := r.validateDynamic(ctx, input, t, withRules)
+				if err := checkContext(ctx); err != nil {
+					return err
 				}
-				if len(errors) != 0 {
-					output[fieldName] = errors
+				if reflect.ValueOf(errors).IsValid() && reflect.ValueOf(errors).Len() != 0 {
+					return errors
 				}
+			}
 
-				if ruleSet.hasDeepValidator() && output[fieldName] == nil && (mapRule(ctx, value) || structRule(ctx, value) || sliceRule(ctx, value)) {
-					data := ruleSet.validateDeepValidator(ctx, value, t)
-
-					if reflect.ValueOf(data).IsValid() && reflect.ValueOf(data).Len() != 0 {
-						output[fieldName] = data
+			switch inputValue.Kind() {
+			case reflect.Slice, reflect.Array:
+				if r.hasChildrenValidator() {
+					o.validateElements(ctx, output, r, inputValue, t, withRules)
+					if err := checkContext(ctx); err != nil {
+						return err
 					}
 				}
-
-				if ruleSet.hasChildrenValidator() && output[fieldName] == nil && sliceRule(ctx, value) {
-					valueOnKeyInput = reflect.ValueOf(valueOnKeyInput.Interface())
-					for i := 0; i < valueOnKeyInput.Len(); i++ {
-						element := valueOnKeyInput.Index(i)
-						errors := ruleSet.validateChildrenValidator(ctx, element.Interface(), t)
-						if reflect.ValueOf(errors).IsValid() && reflect.ValueOf(errors).Len() != 0 {
-							if _, ok := output[fieldName]; !ok {
-								output[fieldName] = map[string]interface{}{}
-							}
-							output[fieldName].(map[string]interface{})[strconv.Itoa(i)] = errors
-						}
+			default:
+				if r.hasDeepValidator() {
+					errors := r.validateDeepValidator(ctx, input, t, withRules)
+					if err := checkContext(ctx); err != nil {
+						return err
+					}
+					if reflect.ValueOf(errors).IsValid() && reflect.ValueOf(errors).Len() != 0 {
+						return errors
 					}
 				}
 			}
-		case reflect.Map:
-			for fieldName, ruleSet := range o.rules {
-				valueOnKeyInput := inputValue.MapIndex(reflect.ValueOf(fieldName))
-				if ruleSet.getName() != "" {
-					fieldName = ruleSet.getName()
-				}
+			if len(output) != 0 {
+				break
+			}
+		}
+	} else {
+		return []string{"invalid validator"}
+	}
 
-				if !valueOnKeyInput.IsValid() {
-					valueOnKeyInput = inputValue.MapIndex(reflect.ValueOf(fieldName))
-				}
-				if !valueOnKeyInput.IsValid() {
-					panic(fmt.Sprintf("value on %s is not valid", fieldName))
-				}
+	if len(output) == 0 {
+		return nil
+	}
 
-				value := valueOnKeyInput.Interface()
-				// Just continue if no requires are set and field is empty, nil or zero
-				requires, isRequired := determineRequires(input, value, ruleSet.getRequires())
-				if (!ruleSet.isRequired() && !isRequired) && isEmptyNilZero(value) {
-					continue
-				}
+	return output
+}
 
-				errors := validate(ruleSet, value, fieldName)
-				dels := []int{}
-				for i, key := range errors {
-					if _, ok := requires[key]; ok {
-						dels = append(dels, i)
-					}
-				}
-				j := 0
-				for _, key := range dels {
-					errors = append(errors[:key-j], errors[key+1-j:]...)
-					j++
+// Validates passed ruleSet on passed input and returns failed rules with their formatted error messages
+//
+// extra options are accessible in error messages of all rules
+func (o *validatorS) validateRuleSet(ctx context.Context, ruleSet ruleSet, onKeyInput interface{}, fieldName string, t Translator, extra option) []failure {
+	for reflect.ValueOf(onKeyInput).IsValid() && reflect.TypeOf(onKeyInput).Kind() == reflect.Ptr {
+		onKeyInputValue := reflect.ValueOf(onKeyInput).Elem()
+		if onKeyInputValue.IsValid() {
+			onKeyInput = onKeyInputValue.Interface()
+		} else {
+			onKeyInput = nil
+		}
+	}
+
+	halfOutput := []failure{}
+	fails := ruleSet.validate(ctx, onKeyInput)
+	if len(fails) != 0 {
+		locale := o.locale
+		if contextLocale := LocaleFromContext(ctx); contextLocale != "" {
+			locale = contextLocale
+		}
+		fieldName = fieldLabel(ruleSet, fieldName, locale, t)
+		for _, failKey := range fails {
+			var m Messages = nil
+			var sm Messages = ruleSet.getSpecificMessages()
+			if o.messages != nil {
+				m = *o.messages
+			}
+			opt := ruleSet.getOption(failKey)
+			if len(extra) != 0 {
+				merged := option{}
+				for key, value := range opt {
+					merged[key] = value
 				}
-				if len(errors) != 0 {
-					output[fieldName] = errors
+				for key, value := range extra {
+					merged[key] = value
 				}
+				opt = merged
+			}
+			value := messageValue(ruleSet, onKeyInput)
+			message := errorMessage(ctx, failKey, fieldName, value, opt, m, o.messageFuncs, sm, ruleSet.getSpecificMessageFuncs(), o.ruleMessages, locale, t)
+			halfOutput = append(halfOutput, failure{rule: failKey, message: message})
+		}
+	}
 
-				if ruleSet.hasDeepValidator() && output[fieldName] == nil && (mapRule(ctx, value) || structRule(ctx, value) || sliceRule(ctx, value)) {
-					data := ruleSet.validateDeepValidator(ctx, value, t)
+	return halfOutput
+}
 
-					if reflect.ValueOf(data).IsValid() && reflect.ValueOf(data).Len() != 0 {
-						output[fieldName] = data
-					}
-				}
+// Returns passed failures if withRules is true, otherwise returns their error messages
+func failuresOutput(failures []failure, withRules bool) interface{} {
+	if withRules {
+		return failures
+	}
+	messages := make([]string, len(failures))
+	for i, f := range failures {
+		messages[i] = f.message
+	}
+	return messages
+}
 
-				if ruleSet.hasChildrenValidator() && output[fieldName] == nil && sliceRule(ctx, value) {
-					valueOnKeyInput = reflect.ValueOf(valueOnKeyInput.Interface())
-					for i := 0; i < valueOnKeyInput.Len(); i++ {
-						element := valueOnKeyInput.Index(i)
-						errors := ruleSet.validateChildrenValidator(ctx, element.Interface(), t)
-						if reflect.ValueOf(errors).IsValid() && reflect.ValueOf(errors).Len() != 0 {
-							if _, ok := output[fieldName]; !ok {
-								output[fieldName] = map[string]interface{}{}
-							}
-							output[fieldName].(map[string]interface{})[strconv.Itoa(i)] = errors
-						}
-					}
-				}
-			}
-		default:
-			return []string{"invalid input"}
+// Validates elements of passed slice by children validator of ruleSet and puts errors of invalid elements
+// in output with their indexes as keys
+func (o *validatorS) validateElements(ctx context.Context, output map[string]interface{}, ruleSet ruleSet, slice reflect.Value, t Translator, withRules bool) {
+	children := ruleSet.getChildrenValidator().(*validatorS)
+	if children.isConcurrent(ctx) {
+		children.validateAll(ctx, output, slice.Len(), func(i int) (string, interface{}) {
+			return o.validateElement(ctx, ruleSet, slice, i, t, withRules)
+		})
+		return
+	}
+	for i := 0; i < slice.Len(); i++ {
+		index, errors := o.validateElement(ctx, ruleSet, slice, i, t, withRules)
+		if checkContext(ctx) != nil {
+			return
 		}
-	} else if o.rule != nil {
-		if !o.rule.isRequired() && isEmptyNilZero(input) {
+		if errors != nil {
+			output[index] = errors
+		}
+	}
+}
+
+// Validates element of passed slice with passed index by children validator of ruleSet
+//
+// Returns index of the element in output and its errors, errors is nil if the element is valid
+func (o *validatorS) validateElement(ctx context.Context, ruleSet ruleSet, slice reflect.Value, i int, t Translator, withRules bool) (string, interface{}) {
+	index := strconv.Itoa(i)
+	errors := ruleSet.validateChildrenValidator(nestedPresence(ctx, index), slice.Index(i).Interface(), t, withRules)
+	if checkContext(ctx) != nil {
+		return index, nil
+	}
+	if reflect.ValueOf(errors).IsValid() && reflect.ValueOf(errors).Len() != 0 {
+		return index, errors
+	}
+	return index, nil
+}
+
+// Finds the value of passed map input which rules with passed key belong to and validates it
+//
+// Returns name of the field in output and its errors
+func (o *validatorS) validateMapKey(ctx context.Context, input interface{}, inputValue reflect.Value, key string, t Translator, withRules bool) (string, interface{}) {
+	ruleSet := o.rules[key]
+	fieldName := key
+	valueOnKeyInput := inputValue.MapIndex(reflect.ValueOf(fieldName))
+	if ruleSet.getName() != "" {
+		fieldName = ruleSet.getName()
+	}
+
+	if !valueOnKeyInput.IsValid() {
+		valueOnKeyInput = inputValue.MapIndex(reflect.ValueOf(fieldName))
+	}
+	if isPartial(ctx) && !isPresent(ctx, valueOnKeyInput.IsValid(), fieldName, key) {
+		return fieldName, nil
+	}
+	if !valueOnKeyInput.IsValid() {
+		panic(fmt.Sprintf("value on %s is not valid", fieldName))
+	}
+
+	return fieldName, o.validateField(ctx, input, ruleSet, valueOnKeyInput.Interface(), fieldName, t, withRules, nil)
+}
+
+// Validates passed field of passed struct input and returns its errors
+//
+// addressableInput is an addressable copy of input which gets created on first unexported field
+func (o *validatorS) validateStructField(ctx context.Context, input interface{}, inputValue reflect.Value, addressableInput *reflect.Value, field *fieldPlan, t Translator, withRules bool) interface{} {
+	valueOnKeyInput := fieldByIndex(inputValue, field.index)
+	// Nil pointers and promoted fields of nil embedded pointers are absent
+	found := valueOnKeyInput.IsValid() && (valueOnKeyInput.Kind() != reflect.Ptr || !valueOnKeyInput.IsNil())
+	if isPartial(ctx) && !isPresent(ctx, found, field.name, field.key) {
+		return nil
+	}
+
+	// If not exported
+	if field.field.PkgPath != "" {
+		if !field.ruleSet.allowsUnexported() {
 			return nil
 		}
+		if !addressableInput.IsValid() {
+			*addressableInput = addressableCopy(inputValue)
+		}
+		valueOnKeyInput = fieldByIndex(*addressableInput, field.index)
+		if valueOnKeyInput.IsValid() {
+			valueOnKeyInput = reflect.NewAt(valueOnKeyInput.Type(), unsafe.Pointer(valueOnKeyInput.UnsafeAddr())).Elem()
+		}
+	}
+	// Promoted fields of a nil embedded pointer are nil
+	var value interface{} = nil
+	if valueOnKeyInput.IsValid() {
+		value = valueOnKeyInput.Interface()
+	}
 
-		errors := validate(o.rule, input, o.rule.getName())
-		if len(errors) != 0 {
-			return errors
+	return o.validateField(ctx, input, field.ruleSet, value, field.name, t, withRules, field)
+}
+
+// Validates value of a field of passed struct or map called all and returns its errors
+//
+// field is the plan of the field if all is a struct, it saves looking up kinds and fields of requires on every call
+//
+// Returns nil if no errors found
+func (o *validatorS) validateField(ctx context.Context, all interface{}, ruleSet ruleSet, value interface{}, fieldName string, t Translator, withRules bool, field *fieldPlan) interface{} {
+	branches := ruleSet.getBranches(ctx, all)
+	// Just continue if no requires are set and field is empty, nil or zero
+	requires, isRequired := determineRequires(ctx, all, value, ruleSet, field)
+	if (!ruleSet.isRequiredIn(ctx) && !isRequired && !branchesAreRequired(ctx, branches)) && isEmptyNilZero(value) {
+		return nil
+	}
+
+	errors := o.validateRuleSet(ctx, ruleSet, value, fieldName, t, nil)
+	for _, b := range branches {
+		errors = append(errors, o.validateRuleSet(ctx, b.rule, value, fieldName, t, option{"branch": b.name})...)
+	}
+	// Rules may fail just because ctx is done
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	dels := []int{}
+	for i, f := range errors {
+		if _, ok := requires[f.message]; ok {
+			dels = append(dels, i)
 		}
+	}
+	j := 0
+	for _, key := range dels {
+		errors = append(errors[:key-j], errors[key+1-j:]...)
+		j++
+	}
+	if len(errors) != 0 {
+		return failuresOutput(errors, withRules)
+	}
 
-		switch inputValue.Kind() {
-		case reflect.Slice:
-			if o.rule.hasChildrenValidator() {
-				for i := 0; i < inputValue.Len(); i++ {
-					element := inputValue.Index(i)
-					errors := o.rule.validateChildrenValidator(ctx, element.Interface(), t)
+	ctx = nestedPresence(ctx, fieldName)
+	// Nested validators of applied branches work like nested validators of the field itself
+	if data := o.validateNested(ctx, ruleSet, value, t, withRules, field); data != nil {
+		return data
+	}
+	for _, b := range branches {
+		if data := o.validateNested(ctx, b.rule, value, t, withRules, field); data != nil {
+			return data
+		}
+	}
 
-					if reflect.ValueOf(errors).IsValid() && reflect.ValueOf(errors).Len() != 0 {
-						if _, ok := output[strconv.Itoa(i)]; !ok {
-							output[strconv.Itoa(i)] = errors
-						}
-					}
-				}
+	return nil
+}
+
+// Validates value of a field by deep, dynamic and children validators of passed ruleSet and returns their errors
+//
+// Returns nil if no errors found
+func (o *validatorS) validateNested(ctx context.Context, ruleSet ruleSet, value interface{}, t Translator, withRules bool, field *fieldPlan) interface{} {
+	// Pointers to structs, maps and slices get validated like values they point to, nil pointers are skipped
+	kind := kindOf(field, value)
+	if ruleSet.hasDeepValidator() && isDeepKind(kind) {
+		data := ruleSet.validateDeepValidator(ctx, value, t, withRules)
+		if err := checkContext(ctx); err != nil {
+			return err
+		}
+
+		if reflect.ValueOf(data).IsValid() && reflect.ValueOf(data).Len() != 0 {
+			return data
+		}
+	}
+
+	if ruleSet.isDynamic() && !isNil(value) {
+		data := ruleSet.validateDynamic(ctx, value, t, withRules)
+		if err := checkContext(ctx); err != nil {
+			return err
+		}
+
+		if reflect.ValueOf(data).IsValid() && reflect.ValueOf(data).Len() != 0 {
+			return data
+		}
+	}
+
+	if ruleSet.hasChildrenValidator() && isSliceKind(kind) {
+		output := map[string]interface{}{}
+		o.validateElements(ctx, output, ruleSet, dereference(reflect.ValueOf(value)), t, withRules)
+		if err := checkContext(ctx); err != nil {
+			return err
+		}
+		if len(output) != 0 {
+			return output
+		}
+	}
+
+	return nil
+}
+
+func (o *validatorS) ValidateField(ctx context.Context, path string, value interface{}, root interface{}, translator ...Translator) (interface{}, error) {
+	var t Translator = nil
+	if len(translator) != 0 {
+		t = translator[0]
+	} else {
+		t = TranslatorFromContext(ctx)
+	}
+
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	output := o.validatePath(ctx, strings.Split(path, "."), value, root, t)
+	switch err := output.(type) {
+	case *PathError:
+		err.Path = path
+		return nil, err
+	case *CanceledError:
+		return nil, err
+	}
+	return output, nil
+}
+
+func (err *PathError) Error() string {
+	return fmt.Sprintf("invalid path %q: %s %s", err.Path, err.Segment, err.Reason)
+}
+
+// Returns true if passed error of ValidateField is a *PathError
+func IsPathError(err error) bool {
+	_, ok := err.(*PathError)
+	return ok
+}
+
+// Resolves rules of passed path segments in o and validates value on them, parent is the input of o
+//
+// Returns a *PathError instead of errors if path does not point to a field with rules
+func (o *validatorS) validatePath(ctx context.Context, segments []string, value interface{}, parent interface{}, t Translator) interface{} {
+	parentValue := reflect.ValueOf(parent)
+	for parentValue.Kind() == reflect.Ptr || parentValue.Kind() == reflect.Interface {
+		parentValue = parentValue.Elem()
+	}
+
+	if o.variants != nil {
+		variant, ok := o.variants[fmt.Sprint(valueOfKey(parentValue, o.discriminatorRule.getName()))]
+		if !ok {
+			// Discriminator has to be fixed first
+			return o.Validate(ctx, parent, t)
+		}
+		return variant.(*validatorS).validatePath(ctx, segments, value, parent, t)
+	} else if o.rules != nil {
+		for key, ruleSet := range o.rules {
+			fieldName := key
+			if ruleSet.getName() != "" {
+				fieldName = ruleSet.getName()
 			}
-		default:
-			if o.rule.hasDeepValidator() {
-				errors := o.rule.validateDeepValidator(ctx, input, t)
-				if reflect.ValueOf(errors).IsValid() && reflect.ValueOf(errors).Len() != 0 {
-					return errors
+			if segments[0] != key && segments[0] != fieldName {
+				continue
+			}
+
+			if len(segments) == 1 {
+				var all interface{} = nil
+				if parentValue.IsValid() {
+					all = parentValue.Interface()
 				}
+				return o.validateField(ctx, all, ruleSet, value, fieldName, t, false, nil)
+			}
+			fieldValue := valueOfKey(parentValue, key)
+			if fieldValue == nil {
+				fieldValue = valueOfKey(parentValue, fieldName)
 			}
+			return o.validateNestedPath(ctx, ruleSet, segments[1:], value, fieldValue, t)
 		}
-	} else {
-		return []string{"invalid validator"}
+	} else if o.rule != nil {
+		return o.validateNestedPath(ctx, o.rule, segments, value, parent, t)
 	}
 
-	if len(output) == 0 {
-		return nil
+	return &PathError{Segment: segments[0], Reason: "has no rules"}
+}
+
+// Resolves rules of passed path segments in deep or children validator of ruleSet and validates value on them
+//
+// fieldValue is the value which ruleSet belongs to
+func (o *validatorS) validateNestedPath(ctx context.Context, ruleSet ruleSet, segments []string, value interface{}, fieldValue interface{}, t Translator) interface{} {
+	if ruleSet.hasDeepValidator() {
+		return ruleSet.getDeepValidator().(*validatorS).validatePath(ctx, segments, value, fieldValue, t)
+	} else if ruleSet.hasChildrenValidator() {
+		index, err := strconv.Atoi(segments[0])
+		if err != nil || index < 0 {
+			return &PathError{Segment: segments[0], Reason: "is not an index"}
+		}
+		children := ruleSet.getChildrenValidator().(*validatorS)
+		if len(segments) == 1 {
+			return children.Validate(ctx, value, t)
+		}
+
+		var element interface{} = nil
+		if elements := reflect.ValueOf(fieldValue); sliceRule(ctx, fieldValue) && index >= 0 && index < elements.Len() {
+			element = elements.Index(index).Interface()
+		}
+		return children.validatePath(ctx, segments[1:], value, element, t)
 	}
 
-	return output
+	return &PathError{Segment: segments[0], Reason: "has no rules"}
+}
+
+// Returns true if a field with passed names is present in input of partial validation
+//
+// If present keys are captured in ctx, names decide presence, otherwise found decides
+func isPresent(ctx context.Context, found bool, names ...string) bool {
+	if keys := presentKeysFromContext(ctx); keys != nil {
+		for _, name := range names {
+			if keys[name] {
+				return true
+			}
+		}
+		return false
+	}
+
+	return found
+}
+
+func (o *validatorS) Compile(samples ...interface{}) Validator {
+	for _, sample := range samples {
+		if sample != nil {
+			o.compile(reflect.TypeOf(sample))
+		}
+	}
+	return o
+}
+
+func (o *validatorS) ValidatePartial(ctx context.Context, input interface{}, translator ...Translator) interface{} {
+	return o.Validate(context.WithValue(ctx, partialContextKey, true), input, translator...)
+}
+
+func (o *validatorS) ValidateGroups(ctx context.Context, input interface{}, groups []string, translator ...Translator) interface{} {
+	return o.Validate(WithGroups(ctx, groups...), input, translator...)
 }
 
 func (o *validatorS) getMessages() *Messages {
@@ -387,21 +921,25 @@ func (o *validatorS) setDefaultOn(input interface{}, defaultInput interface{}, o
 		switch inputValue.Type().Kind() {
 		case reflect.Struct:
 			for fieldName, ruleSet := range o.rules {
-				valueOnKeyInput := inputValue.FieldByName(fieldName)
-				valueOnDefaultKeyInput := defaultValue.FieldByName(fieldName)
+				valueOnKeyInput, _, found := fieldByName(inputValue, fieldName)
+				valueOnDefaultKeyInput, _, defaultFound := fieldByName(defaultValue, fieldName)
 				if ruleSet.getName() != "" {
 					fieldName = ruleSet.getName()
 				}
 
-				if !valueOnKeyInput.IsValid() {
-					valueOnKeyInput = inputValue.FieldByName(fieldName)
+				if !found {
+					valueOnKeyInput, _, found = fieldByName(inputValue, fieldName)
 				}
-				if !valueOnDefaultKeyInput.IsValid() {
-					valueOnDefaultKeyInput = defaultValue.FieldByName(fieldName)
+				if !defaultFound {
+					valueOnDefaultKeyInput, _, defaultFound = fieldByName(defaultValue, fieldName)
 				}
-				if !valueOnKeyInput.IsValid() || !valueOnDefaultKeyInput.IsValid() {
+				if !found || !defaultFound {
 					panic(fmt.Sprintf("value on %s is not valid", fieldName))
 				}
+				// Promoted fields of a nil embedded pointer can not be set
+				if !valueOnKeyInput.IsValid() || !valueOnDefaultKeyInput.IsValid() {
+					continue
+				}
 
 				value := valueOnKeyInput.Interface()
 				if (onNil && isNil(value)) || (onZero && !nonZeroRule(nil, value)) {
@@ -478,6 +1016,14 @@ func getFieldName(path string) string {
 	return strings.SplitN(path, ".", 2)[0]
 }
 
+// Returns name of passed field in errors of DecryptErrors, which is the name that Validate uses for it
+func decryptedName(v Validator, field string) string {
+	if r := v.getRules()[field]; r != nil && r.getName() != "" {
+		return r.getName()
+	}
+	return field
+}
+
 func decryptPath(path string, v Validator, errorField playgroundValidator.FieldError) interface{} {
 	var (
 		splits    = strings.SplitN(path, ".", 2)
@@ -495,9 +1041,17 @@ func decryptPath(path string, v Validator, errorField playgroundValidator.FieldE
 				if r.getName() != "" {
 					fieldName = r.getName()
 				}
-				message := getRawErrorMessage(errorField.Tag(), *v.getMessages(), r.getSpecificMessages(), defaultValidatorErrorMessages)
+				fieldName = fieldLabel(r, fieldName, v.getLocale(), nil)
+				value := messageValue(r, errorField.Value())
+				var message string
+				if f := getMessageFunc(errorField.Tag(), v.getMessageFuncs(), r.getSpecificMessageFuncs(), r.getSpecificMessages()); f != nil {
+					// DecryptErrors does not take a context
+					message = f(context.Background(), fieldName, value, errorField.Tag(), copyOption(r.getOption(errorField.Tag())))
+				} else {
+					message = getRawErrorMessage(errorField.Tag(), *v.getMessages(), r.getSpecificMessages(), v.getRuleMessages(), v.getLocale())
+				}
 				// Do not add translator, translator is for Validate process
-				message = getFormattedErrorMessage(message, fieldName, errorField.Value(), r.getOption(errorField.Tag()))
+				message = getFormattedErrorMessage(message, fieldName, value, r.getOption(errorField.Tag()), v.getLocale())
 				return message
 			} else {
 				panic("error structure does not match with validator structure")
@@ -514,20 +1068,27 @@ func decryptPath(path string, v Validator, errorField playgroundValidator.FieldE
 			arrayItem, _ = strconv.Atoi(slicePieces[2])
 		}
 		if r, ok := rs[fieldName]; ok {
+			if r.getName() != "" {
+				fieldName = r.getName()
+			}
 			if deep := r.getDeepValidator(); deep != nil {
-				if r.getName() != "" {
-					fieldName = r.getName()
-				}
 				output[fieldName] = decryptPath(path, deep, errorField)
 			} else if children := r.getChildrenValidator(); arrayItem != -1 && children != nil {
 				if splits := strings.Split(path, "."); len(splits) == 1 && len(re.FindStringSubmatch(splits[0])) == 0 {
-					output[fieldName] = map[int]interface{}{arrayItem: map[string]interface{}{splits[0]: decryptPath(path, children, errorField)}}
+					output[fieldName] = map[string]interface{}{strconv.Itoa(arrayItem): map[string]interface{}{decryptedName(children, splits[0]): decryptPath(path, children, errorField)}}
 				} else {
-					output[fieldName] = map[int]interface{}{arrayItem: decryptPath(path, children, errorField)}
+					output[fieldName] = map[string]interface{}{strconv.Itoa(arrayItem): decryptPath(path, children, errorField)}
 				}
 			} else {
 				panic("error structure does not match with validator structure")
 			}
+		} else if arrayItem == -1 {
+			// Fields of a flattened embedded struct are placed in rules of its parent
+			out := decryptPath(path, v, errorField)
+			if message, ok := out.(string); ok {
+				return map[string]interface{}{decryptedName(v, getFieldName(path)): message}
+			}
+			return out
 		} else {
 			panic("error structure does not match with validator structure")
 		}
@@ -547,15 +1108,9 @@ func decryptErrors(err error, v Validator, unmarshalError bool) interface{} {
 			path := splits[1]
 			out := decryptPath(path, v, errorField)
 			if outMap, ok := out.(map[string]interface{}); ok && len(outMap) != 0 {
-				for key, value := range outMap {
-					output[key] = value
-				}
+				mergeErrors(output, outMap)
 			} else if outString, ok := out.(string); ok {
-				name := getFieldName(path)
-				if r := v.getRules()[name]; r != nil && r.getName() != "" {
-					name = r.getName()
-				}
-				output[name] = outString
+				output[decryptedName(v, getFieldName(path))] = outString
 			}
 		}
 	} else if e, ok := err.(sliceValidationError); ok {
@@ -589,6 +1144,82 @@ func decryptErrors(err error, v Validator, unmarshalError bool) interface{} {
 	return output
 }
 
+// Merges errors of passed source into destination, errors of elements of the same slice or fields of the same struct
+// do not override each other
+func mergeErrors(destination map[string]interface{}, source map[string]interface{}) {
+	for key, value := range source {
+		destinationMap, ok1 := destination[key].(map[string]interface{})
+		sourceMap, ok2 := value.(map[string]interface{})
+		if ok1 && ok2 {
+			mergeErrors(destinationMap, sourceMap)
+		} else {
+			destination[key] = value
+		}
+	}
+}
+
+func (o *validatorS) Clone() Validator {
+	output := &validatorS{rules: o.rules.clone(), variants: nil, locale: o.locale, ruleMessages: o.ruleMessages}
+	if o.rule != nil {
+		output.rule = o.rule.Clone()
+	}
+	if o.messages != nil {
+		messages := Messages{}
+		for key, value := range *o.messages {
+			messages[key] = value
+		}
+		output.messages = &messages
+	}
+	if o.messageFuncs != nil {
+		output.messageFuncs = MessageFuncs{}
+		for key, value := range o.messageFuncs {
+			output.messageFuncs[key] = value
+		}
+	}
+	if o.discriminatorRule != nil {
+		output.discriminatorRule = o.discriminatorRule.Clone()
+	}
+	if o.variants != nil {
+		output.variants = map[string]Validator{}
+		for key, variant := range o.variants {
+			output.variants[key] = variant.Clone()
+		}
+	}
+	o.plans.Range(func(key, _ interface{}) bool {
+		output.compile(key.(reflect.Type))
+		return true
+	})
+	return output
+}
+
+func (o *validatorS) WithMessageFuncs(messageFuncs MessageFuncs) Validator {
+	output := o.Clone()
+	merged := MessageFuncs{}
+	for key, value := range o.messageFuncs {
+		merged[key] = value
+	}
+	for key, value := range messageFuncs {
+		merged[key] = value
+	}
+	deepPassMessages(output, output.getMessages(), merged, output.getRuleMessages(), output.getLocale())
+	return output
+}
+
+// Returns a copy of rules with cloned ruleSets, returns nil if rules is nil
+func (o Rules) clone() Rules {
+	if o == nil {
+		return nil
+	}
+	output := Rules{}
+	for key, r := range o {
+		if r != nil {
+			r = r.Clone()
+		}
+		output[key] = r
+	}
+	return output
+}
+
 func (o *validatorS) getRules() Rules {
 	return o.rules
 }
@@ -597,6 +1228,34 @@ func (o *validatorS) getRule() ruleSet {
 	return o.rule
 }
 
+func (o *validatorS) getVariants() map[string]Validator {
+	return o.variants
+}
+
 func (o *validatorS) setMessages(messages *Messages) {
 	o.messages = messages
 }
+
+func (o *validatorS) setMessageFuncs(messageFuncs MessageFuncs) {
+	o.messageFuncs = messageFuncs
+}
+
+func (o *validatorS) getMessageFuncs() MessageFuncs {
+	return o.messageFuncs
+}
+
+func (o *validatorS) setRuleMessages(messages ruleMessages) {
+	o.ruleMessages = messages
+}
+
+func (o *validatorS) getRuleMessages() ruleMessages {
+	return o.ruleMessages
+}
+
+func (o *validatorS) setLocale(locale string) {
+	o.locale = locale
+}
+
+func (o *validatorS) getLocale() string {
+	return o.locale
+}